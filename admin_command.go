@@ -0,0 +1,54 @@
+package main
+
+import (
+	"PilaiteProject/internal/service"
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// runCreateAdmin handles "create-admin --email EMAIL". The password is the first line of
+// stdin, so it stays out of the shell history and the process list. An existing account is
+// only made an admin with --promote, which keeps its password and reads nothing from stdin.
+func runCreateAdmin(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	email := flags.String("email", "", "email address of the admin")
+	promote := flags.Bool("promote", false, "make an existing user an admin, keeping their password")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || flags.NArg() > 0 {
+		return errors.New("usage: create-admin --email EMAIL < password | --promote --email EMAIL")
+	}
+
+	users := service.NewUserService(app.conn.Queries)
+	if *promote {
+		user, err := users.PromoteAdmin(ctx, *email)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.stdout, "promoted %s (id %d) to admin\n", user.Email, user.ID)
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(app.stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return fmt.Errorf("failed to read the password from stdin: %w", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+
+	user, err := users.CreateAdmin(ctx, *email, password)
+	if errors.Is(err, service.ErrUserExists) {
+		return fmt.Errorf("%w, use --promote to make it an admin", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "created admin %s (id %d)\n", user.Email, user.ID)
+	return nil
+}
@@ -0,0 +1 @@
+ALTER TABLE image ADD COLUMN thumbnail_url VARCHAR(512) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE image DROP COLUMN IF EXISTS original_metadata;
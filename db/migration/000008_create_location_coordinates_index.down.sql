@@ -0,0 +1 @@
+DROP INDEX IF EXISTS location_coordinates_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS location_coordinates_idx ON location (latitude, longitude);
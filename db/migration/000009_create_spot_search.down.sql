@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS location_search_idx;
+DROP INDEX IF EXISTS spot_search_idx;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS lithuanian_unaccent;
+DROP EXTENSION IF EXISTS unaccent;
@@ -0,0 +1,12 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- simple keeps words as they are (no stemming), unaccent folds diacritics first,
+-- so 'zalias' and 'Žalias' produce the same lexeme
+CREATE TEXT SEARCH CONFIGURATION lithuanian_unaccent (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION lithuanian_unaccent
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+
+CREATE INDEX IF NOT EXISTS spot_search_idx ON spot
+    USING GIN (to_tsvector('lithuanian_unaccent', name || ' ' || description));
+CREATE INDEX IF NOT EXISTS location_search_idx ON location
+    USING GIN (to_tsvector('lithuanian_unaccent', address));
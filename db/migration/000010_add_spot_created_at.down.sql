@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS spot_created_at_id_idx;
+DROP INDEX IF EXISTS spot_name_id_idx;
+ALTER TABLE spot DROP COLUMN IF EXISTS created_at;
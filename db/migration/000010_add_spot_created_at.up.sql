@@ -0,0 +1,5 @@
+ALTER TABLE spot ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Keyset pagination indexes for the name and newest sort orders
+CREATE INDEX IF NOT EXISTS spot_name_id_idx ON spot (name, id);
+CREATE INDEX IF NOT EXISTS spot_created_at_id_idx ON spot (created_at DESC, id DESC);
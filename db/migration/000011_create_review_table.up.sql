@@ -0,0 +1,14 @@
+CREATE TABLE review
+(
+    id         BIGSERIAL PRIMARY KEY,
+    spot_id    BIGINT        NOT NULL,
+    user_id    BIGINT        NOT NULL,
+    rating     SMALLINT      NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment    VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_review_spot_id FOREIGN KEY (spot_id) REFERENCES spot (id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    -- One review per user per spot, also serves the per spot aggregates
+    CONSTRAINT review_spot_user_unique UNIQUE (spot_id, user_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE favorite
+(
+    user_id    BIGINT    NOT NULL,
+    spot_id    BIGINT    NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, spot_id),
+    CONSTRAINT fk_favorite_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_favorite_spot_id FOREIGN KEY (spot_id) REFERENCES spot (id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS submission_image;
+DROP TABLE IF EXISTS spot_submission;
+DROP TYPE IF EXISTS submission_status;
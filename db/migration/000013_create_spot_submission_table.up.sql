@@ -0,0 +1,36 @@
+CREATE TYPE submission_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE spot_submission
+(
+    id               BIGSERIAL PRIMARY KEY,
+    user_id          BIGINT            NOT NULL,
+    category         spot_category     NOT NULL,
+    name             VARCHAR(50)       NOT NULL,
+    description      VARCHAR(255)      NOT NULL,
+    address          VARCHAR(30)       NOT NULL,
+    latitude         DECIMAL(10, 8)    NOT NULL,
+    longitude        DECIMAL(11, 8)    NOT NULL,
+    status           submission_status NOT NULL DEFAULT 'pending',
+    rejection_reason VARCHAR(500)      NOT NULL DEFAULT '',
+    -- The spot created on approval and the admin who approved or rejected
+    spot_id          BIGINT,
+    reviewed_by      BIGINT,
+    created_at       TIMESTAMP         NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP         NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_submission_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_submission_spot_id FOREIGN KEY (spot_id) REFERENCES spot (id) ON DELETE SET NULL,
+    CONSTRAINT fk_submission_reviewed_by FOREIGN KEY (reviewed_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS spot_submission_status_idx ON spot_submission (status, created_at);
+CREATE INDEX IF NOT EXISTS spot_submission_user_id_idx ON spot_submission (user_id);
+
+CREATE TABLE submission_image
+(
+    id                BIGSERIAL PRIMARY KEY,
+    submission_id     BIGINT       NOT NULL,
+    url               VARCHAR(512) NOT NULL UNIQUE,
+    thumbnail_url     VARCHAR(512) NOT NULL,
+    original_metadata JSONB,
+    CONSTRAINT fk_submission_image_submission_id FOREIGN KEY (submission_id) REFERENCES spot_submission (id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS opening_hours_exception;
+DROP TABLE IF EXISTS opening_hours;
@@ -0,0 +1,31 @@
+-- Weekly opening hours, evaluated in Europe/Vilnius time. A shift belongs to the day it
+-- opens on, closes <= opens means it ends on the next day. Several rows per weekday
+-- describe split shifts, 00:00-24:00 is open all day.
+CREATE TABLE opening_hours
+(
+    id      BIGSERIAL PRIMARY KEY,
+    spot_id BIGINT   NOT NULL,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+    opens   TIME     NOT NULL,
+    closes  TIME     NOT NULL CHECK (closes <> opens),
+    CONSTRAINT fk_opening_hours_spot_id FOREIGN KEY (spot_id) REFERENCES spot (id) ON DELETE CASCADE
+);
+
+CREATE INDEX opening_hours_spot_id_idx ON opening_hours (spot_id);
+
+-- Holidays and other exception dates replace the weekly shifts opening on that date.
+-- A row without times means the spot is closed the whole day.
+CREATE TABLE opening_hours_exception
+(
+    id      BIGSERIAL PRIMARY KEY,
+    spot_id BIGINT       NOT NULL,
+    date    DATE         NOT NULL,
+    opens   TIME,
+    closes  TIME,
+    note    VARCHAR(200) NOT NULL DEFAULT '',
+    CONSTRAINT fk_opening_hours_exception_spot_id FOREIGN KEY (spot_id) REFERENCES spot (id) ON DELETE CASCADE,
+    CONSTRAINT opening_hours_exception_times CHECK ((opens IS NULL) = (closes IS NULL) AND opens <> closes)
+);
+
+CREATE INDEX opening_hours_exception_spot_id_date_idx ON opening_hours_exception (spot_id, date);
+CREATE INDEX opening_hours_exception_date_idx ON opening_hours_exception (date);
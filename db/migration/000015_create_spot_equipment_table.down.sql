@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS spot_equipment;
+DROP TYPE IF EXISTS equipment_condition;
+DROP TYPE IF EXISTS equipment_type;
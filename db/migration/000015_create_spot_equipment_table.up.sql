@@ -0,0 +1,32 @@
+CREATE TYPE equipment_type AS ENUM (
+    'pull_up_bar',
+    'parallel_bars',
+    'horizontal_ladder',
+    'push_up_bars',
+    'dip_station',
+    'sit_up_bench',
+    'leg_press',
+    'chest_press',
+    'air_walker',
+    'rowing_machine',
+    'rings',
+    'other'
+    );
+
+CREATE TYPE equipment_condition AS ENUM ('good', 'worn', 'broken');
+
+-- Inventory of outdoor gym spots, one row per kind of equipment
+CREATE TABLE spot_equipment
+(
+    id         BIGSERIAL PRIMARY KEY,
+    spot_id    BIGINT              NOT NULL,
+    type       equipment_type      NOT NULL,
+    quantity   INTEGER             NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    condition  equipment_condition NOT NULL DEFAULT 'good',
+    updated_at TIMESTAMP           NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_spot_equipment_spot_id FOREIGN KEY (spot_id) REFERENCES spot (id) ON DELETE CASCADE,
+    UNIQUE (spot_id, type)
+);
+
+-- The equipment filter looks up spots by type
+CREATE INDEX spot_equipment_type_idx ON spot_equipment (type, spot_id);
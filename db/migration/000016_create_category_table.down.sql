@@ -0,0 +1,14 @@
+-- Fails while spots use a category that did not exist in the enum
+CREATE TYPE spot_category AS ENUM ('Gamta', 'Lauko_treniruokliai', 'Slaptos_vietos', 'Restoranai', 'Parduotuves');
+
+DROP INDEX IF EXISTS spot_category_idx;
+
+ALTER TABLE spot_submission
+    DROP CONSTRAINT fk_spot_submission_category,
+    ALTER COLUMN category TYPE spot_category USING category::spot_category;
+
+ALTER TABLE spot
+    DROP CONSTRAINT fk_spot_category,
+    ALTER COLUMN category TYPE spot_category USING category::spot_category;
+
+DROP TABLE IF EXISTS category;
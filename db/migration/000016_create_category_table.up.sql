@@ -0,0 +1,34 @@
+-- Categories used to be the spot_category enum, so adding one needed a migration and a deploy.
+-- The slugs stay the same, so existing spots, URLs and clients keep working.
+CREATE TABLE category
+(
+    slug          VARCHAR(50) PRIMARY KEY CHECK (slug ~ '^[A-Za-z0-9_-]+$'),
+    -- Display names by language code, e.g. {"lt": "Gamta", "en": "Nature"}
+    names         JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    icon          VARCHAR(50) NOT NULL DEFAULT '',
+    sort_order    INTEGER     NOT NULL DEFAULT 0,
+    -- Spots in these categories are only shown to logged in users
+    requires_auth BOOLEAN     NOT NULL DEFAULT FALSE,
+    -- Spots in these categories can list their equipment
+    has_equipment BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at    TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO category (slug, names, icon, sort_order, requires_auth, has_equipment)
+VALUES ('Gamta', '{"lt": "Gamta", "en": "Nature"}', 'tree', 1, FALSE, FALSE),
+       ('Lauko_treniruokliai', '{"lt": "Lauko treniruokliai", "en": "Outdoor gyms"}', 'dumbbell', 2, FALSE, TRUE),
+       ('Slaptos_vietos', '{"lt": "Slaptos vietos", "en": "Secret spots"}', 'lock', 3, TRUE, FALSE),
+       ('Restoranai', '{"lt": "Restoranai", "en": "Restaurants"}', 'utensils', 4, FALSE, FALSE),
+       ('Parduotuves', '{"lt": "Parduotuvės", "en": "Shops"}', 'shopping-bag', 5, FALSE, FALSE);
+
+ALTER TABLE spot
+    ALTER COLUMN category TYPE VARCHAR(50) USING category::text,
+    ADD CONSTRAINT fk_spot_category FOREIGN KEY (category) REFERENCES category (slug) ON UPDATE CASCADE;
+
+ALTER TABLE spot_submission
+    ALTER COLUMN category TYPE VARCHAR(50) USING category::text,
+    ADD CONSTRAINT fk_spot_submission_category FOREIGN KEY (category) REFERENCES category (slug) ON UPDATE CASCADE;
+
+CREATE INDEX spot_category_idx ON spot (category);
+
+DROP TYPE spot_category;
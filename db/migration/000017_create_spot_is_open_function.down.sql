@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS spot_is_open(BIGINT, DATE, TIME);
@@ -0,0 +1,35 @@
+-- spot_is_open mirrors the Go schedule rules for the open now filter, so the list
+-- queries only read the schedules of the spots they look at. The date and time are
+-- the Europe/Vilnius wall clock. A spot is open when a shift opening today, or an
+-- overnight shift from yesterday, covers the time. Exception dates replace the weekly
+-- shifts opening on them and only apply to spots that have weekly hours.
+CREATE FUNCTION spot_is_open(p_spot_id BIGINT, p_date DATE, p_time TIME) RETURNS BOOLEAN
+    LANGUAGE sql
+    STABLE
+AS
+$$
+WITH days AS (SELECT p_date AS day, TRUE AS is_today
+              UNION ALL
+              SELECT p_date - 1, FALSE),
+     shifts AS (SELECT d.is_today, e.opens, e.closes
+                FROM days d
+                         INNER JOIN opening_hours_exception e ON e.spot_id = p_spot_id AND e.date = d.day
+                WHERE e.opens IS NOT NULL
+                UNION ALL
+                SELECT d.is_today, h.opens, h.closes
+                FROM days d
+                         INNER JOIN opening_hours h
+                                    ON h.spot_id = p_spot_id AND h.weekday = extract(ISODOW FROM d.day)
+                WHERE NOT EXISTS (SELECT 1
+                                  FROM opening_hours_exception e
+                                  WHERE e.spot_id = p_spot_id
+                                    AND e.date = d.day))
+SELECT EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = p_spot_id)
+           AND EXISTS (SELECT 1
+                       FROM shifts
+                       WHERE CASE
+                                 WHEN is_today THEN opens <= p_time AND (p_time < closes OR closes <= opens)
+                                 -- Only the part of yesterday's overnight shifts after midnight
+                                 ELSE closes <= opens AND p_time < closes
+                                 END)
+$$;
@@ -0,0 +1,9 @@
+// Package migration embeds the schema migrations so the binary can apply them itself
+package migration
+
+import "embed"
+
+// FS holds the NNNNNN_name.up.sql and NNNNNN_name.down.sql files
+//
+//go:embed *.sql
+var FS embed.FS
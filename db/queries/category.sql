@@ -0,0 +1,32 @@
+-- name: GetCategories :many
+SELECT * FROM category
+ORDER BY sort_order, slug;
+
+-- name: GetCategoryBySlug :one
+SELECT * FROM category WHERE slug = $1;
+
+-- name: InsertCategory :one
+-- New categories are added at the end of the list, an existing slug returns no rows
+INSERT INTO category(
+    slug, names, icon, requires_auth, has_equipment, sort_order
+) VALUES (
+             $1, $2, $3, $4, $5, (SELECT COALESCE(max(sort_order), 0) + 1 FROM category)
+         )
+ON CONFLICT (slug) DO NOTHING
+RETURNING *;
+
+-- name: UpdateCategory :one
+UPDATE category
+SET names         = $2,
+    icon          = $3,
+    requires_auth = $4,
+    has_equipment = $5
+WHERE slug = $1
+RETURNING *;
+
+-- name: ReorderCategories :exec
+-- Sets sort_order to the position of each slug in the given list
+UPDATE category c
+SET sort_order = o.position
+FROM unnest(@slugs::text[]) WITH ORDINALITY AS o(slug, position)
+WHERE c.slug = o.slug;
@@ -0,0 +1,26 @@
+-- name: GetSpotEquipment :many
+SELECT * FROM spot_equipment
+WHERE spot_id = $1
+ORDER BY type;
+
+-- name: UpsertSpotEquipment :one
+-- Each kind of equipment is listed once per spot, setting it again replaces quantity and condition
+INSERT INTO spot_equipment(
+    spot_id, type, quantity, condition
+) VALUES (
+             $1, $2, $3, $4
+         )
+ON CONFLICT (spot_id, type) DO UPDATE
+    SET quantity   = EXCLUDED.quantity,
+        condition  = EXCLUDED.condition,
+        updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteSpotEquipment :one
+DELETE FROM spot_equipment
+WHERE spot_id = $1 AND type = $2
+RETURNING *;
+
+-- name: DeleteSpotEquipmentBySpotID :exec
+DELETE FROM spot_equipment
+WHERE spot_id = $1;
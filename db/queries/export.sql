@@ -0,0 +1,20 @@
+-- name: ExportSpots :many
+-- Every visible spot with its first photo, for the map file exports. Spots come
+-- grouped by category in display order so KML folders can be written in one pass
+SELECT
+    s.id,
+    s.name,
+    s.category,
+    s.description,
+    l.address,
+    l.latitude,
+    l.longitude,
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id ORDER BY id LIMIT 1), '')::text AS image_url
+FROM spot s
+         INNER JOIN location l ON s.location_id = l.id
+         INNER JOIN category c ON s.category = c.slug
+WHERE (@include_secret::boolean OR NOT c.requires_auth)
+  AND (cardinality(@categories::text[]) = 0 OR s.category = ANY (@categories::text[]))
+  AND (NOT @filter_bbox::boolean OR (l.latitude BETWEEN @min_lat::float8 AND @max_lat::float8
+    AND l.longitude BETWEEN @min_lng::float8 AND @max_lng::float8))
+ORDER BY c.sort_order, c.slug, s.id;
@@ -0,0 +1,33 @@
+-- name: AddFavorite :exec
+INSERT INTO favorite(
+    user_id, spot_id
+) VALUES (
+             $1, $2
+         )
+ON CONFLICT (user_id, spot_id) DO NOTHING;
+
+-- name: RemoveFavorite :exec
+DELETE FROM favorite WHERE user_id = $1 AND spot_id = $2;
+
+-- name: GetFavoriteSpotIDs :many
+-- Returns which of the given spots the user saved
+SELECT spot_id FROM favorite
+WHERE user_id = @user_id AND spot_id = ANY (@spot_ids::bigint[]);
+
+-- name: GetFavoriteSpots :many
+SELECT
+    s.id,
+    s.name,
+    s.category,
+    l.address,
+    l.latitude,
+    l.longitude,
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours
+FROM favorite f
+         INNER JOIN spot s ON f.spot_id = s.id
+         INNER JOIN location l ON s.location_id = l.id
+WHERE f.user_id = $1
+ORDER BY f.created_at DESC, s.id;
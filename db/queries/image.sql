@@ -1,12 +1,23 @@
 -- name: InsertImage :one
 INSERT INTO image(
-    url, spot_id
+    url, thumbnail_url, spot_id, original_metadata
 ) VALUES (
-             $1, $2
+             $1, $2, $3, $4
          )RETURNING *;
 
 -- name: GetImageByID :one
 SELECT * FROM image WHERE id = $1;
 
 -- name: GetAllImages :many
-SELECT * FROM image;
\ No newline at end of file
+SELECT * FROM image;
+
+-- name: GetImagesBySpotID :many
+SELECT * FROM image WHERE spot_id = $1 ORDER BY id;
+
+-- name: DeleteImage :one
+DELETE FROM image WHERE id = $1 AND spot_id = $2
+RETURNING *;
+
+-- name: DeleteImagesBySpotID :many
+DELETE FROM image WHERE spot_id = $1
+RETURNING *;
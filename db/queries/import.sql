@@ -0,0 +1,10 @@
+-- name: GetDuplicateSpotID :one
+-- A spot with the same name, ignoring case, about 50 meters or closer
+SELECT s.id
+FROM spot s
+         INNER JOIN location l ON s.location_id = l.id
+WHERE lower(s.name) = lower(@name)
+  AND abs(l.latitude - @latitude::float8) < 0.0005
+  AND abs(l.longitude - @longitude::float8) < 0.0005
+ORDER BY s.id
+LIMIT 1;
@@ -9,4 +9,15 @@ INSERT INTO location(
 SELECT * FROM location WHERE id = $1;
 
 -- name: GetAllLocations :many
-SELECT * FROM location;
\ No newline at end of file
+SELECT * FROM location;
+
+-- name: UpdateLocation :one
+UPDATE location
+SET address   = $2,
+    latitude  = $3,
+    longitude = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLocation :exec
+DELETE FROM location WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: GetOpeningHoursBySpotIDs :many
+SELECT * FROM opening_hours
+WHERE spot_id = ANY (@spot_ids::bigint[])
+ORDER BY spot_id, weekday, opens;
+
+-- name: InsertOpeningHours :exec
+INSERT INTO opening_hours(
+    spot_id, weekday, opens, closes
+) VALUES (
+             $1, $2, $3, $4
+         );
+
+-- name: DeleteOpeningHours :exec
+DELETE FROM opening_hours WHERE spot_id = $1;
+
+-- name: GetOpeningHoursExceptionsBySpotIDs :many
+SELECT * FROM opening_hours_exception
+WHERE spot_id = ANY (@spot_ids::bigint[])
+  AND date BETWEEN @from_date::date AND @to_date::date
+ORDER BY spot_id, date, opens NULLS FIRST;
+
+-- name: InsertOpeningHoursException :exec
+INSERT INTO opening_hours_exception(
+    spot_id, date, opens, closes, note
+) VALUES (
+             $1, $2, $3, $4, $5
+         );
+
+-- name: DeleteOpeningHoursExceptions :exec
+DELETE FROM opening_hours_exception WHERE spot_id = $1;
@@ -0,0 +1,36 @@
+-- name: InsertReview :one
+-- Returns no rows when the user already reviewed the spot
+INSERT INTO review(
+    spot_id, user_id, rating, comment
+) VALUES (
+             $1, $2, $3, $4
+         )
+ON CONFLICT (spot_id, user_id) DO NOTHING
+RETURNING *;
+
+-- name: GetReviewByID :one
+SELECT * FROM review WHERE id = $1;
+
+-- name: GetReviewsBySpotID :many
+SELECT * FROM review
+WHERE spot_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetSpotRating :one
+SELECT
+    count(*)::bigint AS review_count,
+    COALESCE(round(avg(rating), 2), 0)::float8 AS average_rating
+FROM review
+WHERE spot_id = $1;
+
+-- name: UpdateReview :one
+UPDATE review
+SET rating     = $2,
+    comment    = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteReview :one
+DELETE FROM review WHERE id = $1
+RETURNING *;
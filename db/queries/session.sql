@@ -0,0 +1,5 @@
+-- name: CountActiveSessions :one
+-- Sessions that have not expired yet, expired rows wait for the store cleanup
+SELECT count(*)
+FROM sessions
+WHERE expiry > now();
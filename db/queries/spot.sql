@@ -8,10 +8,58 @@ INSERT INTO spot(
 -- name: GetSpotByID :one
 SELECT * FROM spot WHERE id = $1;
 
+-- name: GetSpotByIDForUpdate :one
+-- Locks the row so concurrent partial updates don't overwrite each other
+SELECT * FROM spot WHERE id = $1 FOR UPDATE;
+
 -- name: GetAllSpots :many
 SELECT * FROM spot;
 
--- name: GetPublicSpotsWithDetails :many
+-- name: UpdateSpot :one
+UPDATE spot
+SET category    = $2,
+    name        = $3,
+    description = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSpot :one
+DELETE FROM spot WHERE id = $1
+RETURNING *;
+
+-- name: GetSpotsNearby :many
+-- The bounding box on the raw columns can use location_coordinates_idx,
+-- the exact haversine distance is only computed for rows inside the box
+SELECT id, name, category, address, latitude, longitude, image_url, review_count, average_rating, has_opening_hours, distance_m
+FROM (
+    SELECT
+        s.id,
+        s.name,
+        s.category,
+        l.address,
+        l.latitude::float8 AS latitude,
+        l.longitude::float8 AS longitude,
+        COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+        (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+        (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+        EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+        (2 * 6371000 * asin(sqrt(
+            power(sin(radians(l.latitude::float8 - @lat::float8) / 2), 2) +
+            cos(radians(@lat::float8)) * cos(radians(l.latitude::float8)) *
+            power(sin(radians(l.longitude::float8 - @lng::float8) / 2), 2)
+        )))::float8 AS distance_m
+    FROM spot s
+             INNER JOIN location l ON s.location_id = l.id
+             INNER JOIN category c ON s.category = c.slug
+    WHERE l.latitude BETWEEN @min_lat AND @max_lat
+      AND l.longitude BETWEEN @min_lng AND @max_lng
+      AND (@include_secret::boolean OR NOT c.requires_auth)
+) AS nearby
+WHERE distance_m <= @radius_m::float8
+ORDER BY distance_m, id
+LIMIT @max_results;
+
+-- name: GetSpotsInBBox :many
 SELECT
     s.id,
     s.name,
@@ -19,13 +67,43 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text as image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-WHERE s.category != 'Slaptos_vietos'
-ORDER BY s.id;
+         INNER JOIN category c ON s.category = c.slug
+WHERE l.latitude BETWEEN @min_lat AND @max_lat
+  AND l.longitude BETWEEN @min_lng AND @max_lng
+  AND (@include_secret::boolean OR NOT c.requires_auth)
+ORDER BY s.id
+LIMIT @max_results;
 
--- name: GetSpotsWithDetails :many
+-- name: GetSpotClustersInBBox :many
+-- Groups spots into a grid of cell_size degrees, per category so the
+-- caller can build the category breakdown of each cell
+SELECT
+    floor(l.latitude::float8 / @cell_size::float8)::bigint AS cell_y,
+    floor(l.longitude::float8 / @cell_size::float8)::bigint AS cell_x,
+    s.category,
+    count(*)::bigint AS spot_count,
+    avg(l.latitude::float8)::float8 AS avg_latitude,
+    avg(l.longitude::float8)::float8 AS avg_longitude,
+    min(s.id)::bigint AS sample_id
+FROM spot s
+         INNER JOIN location l ON s.location_id = l.id
+         INNER JOIN category c ON s.category = c.slug
+WHERE l.latitude BETWEEN @min_lat AND @max_lat
+  AND l.longitude BETWEEN @min_lng AND @max_lng
+  AND (@include_secret::boolean OR NOT c.requires_auth)
+GROUP BY cell_y, cell_x, s.category
+ORDER BY cell_y, cell_x, s.category
+LIMIT @max_results;
+
+-- name: SearchSpots :many
+-- Matches name, description and address with diacritics folded, best matches first.
+-- The snippet marks matched words with \x02 and \x03 so they can be escaped safely.
 SELECT
     s.id,
     s.name,
@@ -33,12 +111,35 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text as image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    ts_rank(
+        setweight(to_tsvector('lithuanian_unaccent', s.name), 'A') ||
+        setweight(to_tsvector('lithuanian_unaccent', s.description), 'B') ||
+        setweight(to_tsvector('lithuanian_unaccent', l.address), 'C'),
+        q.query
+    )::float8 AS rank,
+    ts_headline(
+        'lithuanian_unaccent',
+        s.name || ' - ' || s.description || ' - ' || l.address,
+        q.query,
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=25, MinWords=10'
+    )::text AS snippet
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-ORDER BY s.id;
+         INNER JOIN category c ON s.category = c.slug
+         CROSS JOIN websearch_to_tsquery('lithuanian_unaccent', @query::text) AS q(query)
+WHERE (to_tsvector('lithuanian_unaccent', s.name || ' ' || s.description) @@ q.query
+    OR to_tsvector('lithuanian_unaccent', l.address) @@ q.query)
+  AND (@include_secret::boolean OR NOT c.requires_auth)
+ORDER BY rank DESC, s.id
+LIMIT @max_results;
 
--- name: GetPublicSpotsByCategoryWithDetails :many
+-- name: ListSpotsByID :many
+-- Keyset paginated spot list in id order, the cursor is the last id of the previous page.
+-- One query per sort order keeps ORDER BY static, so the matching index is used.
 SELECT
     s.id,
     s.name,
@@ -46,13 +147,27 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text as image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    s.created_at
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-WHERE s.category = $1 AND s.category != 'Slaptos_vietos'
-ORDER BY s.id;
+         INNER JOIN category c ON s.category = c.slug
+WHERE (@include_secret::boolean OR NOT c.requires_auth)
+  AND (cardinality(@categories::text[]) = 0 OR s.category = ANY (@categories::text[]))
+  AND (NOT @filter_open::boolean OR spot_is_open(s.id, @open_date::date, @open_time::time))
+  AND (cardinality(@equipment::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY (@equipment::text[]) AND e.condition != 'broken'
+  ) = cardinality(@equipment::text[]))
+  AND (NOT @has_cursor::boolean OR s.id > @cursor_id::bigint)
+ORDER BY s.id
+LIMIT @max_results;
 
--- name: GetSpotsByCategoryWithDetails :many
+-- name: ListSpotsByName :many
+-- ListSpotsByID sorted by name, ties are broken by id. Uses spot_name_id_idx.
 SELECT
     s.id,
     s.name,
@@ -60,8 +175,61 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    s.created_at
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-WHERE s.category = $1
-ORDER BY s.id;
+         INNER JOIN category c ON s.category = c.slug
+WHERE (@include_secret::boolean OR NOT c.requires_auth)
+  AND (cardinality(@categories::text[]) = 0 OR s.category = ANY (@categories::text[]))
+  AND (NOT @filter_open::boolean OR spot_is_open(s.id, @open_date::date, @open_time::time))
+  AND (cardinality(@equipment::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY (@equipment::text[]) AND e.condition != 'broken'
+  ) = cardinality(@equipment::text[]))
+  AND (NOT @has_cursor::boolean OR (s.name, s.id) > (@cursor_name::text, @cursor_id::bigint))
+ORDER BY s.name, s.id
+LIMIT @max_results;
+
+-- name: ListSpotsByNewest :many
+-- ListSpotsByID with the newest spots first, ties are broken by id. Uses spot_created_at_id_idx.
+SELECT
+    s.id,
+    s.name,
+    s.category,
+    l.address,
+    l.latitude,
+    l.longitude,
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    s.created_at
+FROM spot s
+         INNER JOIN location l ON s.location_id = l.id
+         INNER JOIN category c ON s.category = c.slug
+WHERE (@include_secret::boolean OR NOT c.requires_auth)
+  AND (cardinality(@categories::text[]) = 0 OR s.category = ANY (@categories::text[]))
+  AND (NOT @filter_open::boolean OR spot_is_open(s.id, @open_date::date, @open_time::time))
+  AND (cardinality(@equipment::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY (@equipment::text[]) AND e.condition != 'broken'
+  ) = cardinality(@equipment::text[]))
+  AND (NOT @has_cursor::boolean OR (s.created_at, s.id) < (@cursor_created_at::timestamp, @cursor_id::bigint))
+ORDER BY s.created_at DESC, s.id DESC
+LIMIT @max_results;
+
+-- name: CountSpots :one
+SELECT count(*)
+FROM spot s
+         INNER JOIN category c ON s.category = c.slug
+WHERE (@include_secret::boolean OR NOT c.requires_auth)
+  AND (cardinality(@categories::text[]) = 0 OR s.category = ANY (@categories::text[]))
+  AND (NOT @filter_open::boolean OR spot_is_open(s.id, @open_date::date, @open_time::time))
+  AND (cardinality(@equipment::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY (@equipment::text[]) AND e.condition != 'broken'
+  ) = cardinality(@equipment::text[]));
@@ -0,0 +1,80 @@
+-- name: InsertSubmission :one
+INSERT INTO spot_submission(
+    user_id, category, name, description, address, latitude, longitude
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7
+         ) RETURNING *;
+
+-- name: GetSubmissionByID :one
+SELECT * FROM spot_submission WHERE id = $1;
+
+-- name: GetSubmissionByIDForUpdate :one
+-- Locks the row so two admins can't moderate the same submission at once
+SELECT * FROM spot_submission WHERE id = $1 FOR UPDATE;
+
+-- name: GetSubmissionsByStatus :many
+SELECT * FROM spot_submission
+WHERE status = $1
+ORDER BY created_at, id;
+
+-- name: GetSubmissionsByUserID :many
+SELECT * FROM spot_submission
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateSubmission :one
+UPDATE spot_submission
+SET category    = $2,
+    name        = $3,
+    description = $4,
+    address     = $5,
+    latitude    = $6,
+    longitude   = $7,
+    updated_at  = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: ApproveSubmission :one
+UPDATE spot_submission
+SET status      = 'approved',
+    spot_id     = $2,
+    reviewed_by = $3,
+    updated_at  = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: RejectSubmission :one
+UPDATE spot_submission
+SET status           = 'rejected',
+    rejection_reason = $2,
+    reviewed_by      = $3,
+    updated_at       = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: InsertSubmissionImage :one
+INSERT INTO submission_image(
+    submission_id, url, thumbnail_url, original_metadata
+) VALUES (
+             $1, $2, $3, $4
+         ) RETURNING *;
+
+-- name: GetSubmissionImages :many
+SELECT * FROM submission_image WHERE submission_id = $1 ORDER BY id;
+
+-- name: DeleteSubmissionImages :many
+DELETE FROM submission_image WHERE submission_id = $1
+RETURNING *;
+
+-- name: MoveSubmissionImagesToSpot :exec
+-- Turns the photos of an approved submission into images of the new spot. The
+-- submission rows go away, the files belong to the spot images from now on.
+WITH moved AS (
+    DELETE FROM submission_image
+    WHERE submission_id = @submission_id
+    RETURNING id, url, thumbnail_url, original_metadata
+)
+INSERT INTO image (url, thumbnail_url, spot_id, original_metadata)
+SELECT url, thumbnail_url, @spot_id::bigint, original_metadata
+FROM moved
+ORDER BY id;
@@ -14,3 +14,10 @@ SELECT * FROM users WHERE email = $1;
 -- name: GetAllUsers :many
 SELECT * FROM users;
 
+-- name: PromoteUserToAdmin :one
+UPDATE users
+SET role       = 'admin',
+    updated_at = CURRENT_TIMESTAMP
+WHERE email = $1
+RETURNING *;
+
@@ -0,0 +1,9 @@
+// Package seed embeds the sample spots the seed command imports into a fresh database
+package seed
+
+import _ "embed"
+
+// SpotsCSV is in the import format of POST /spots/import
+//
+//go:embed spots.csv
+var SpotsCSV []byte
@@ -16,16 +16,31 @@ async function doFetch(path, opts = {}) {
     return { res, body };
 }
 
+// fetchAllSpots follows next_cursor until the last page, the list is paginated
+async function fetchAllSpots(path, opts = {}) {
+    const spots = [];
+    let cursor = null;
+    do {
+        const params = new URLSearchParams({ limit: '100' });
+        if (cursor) params.set('cursor', cursor);
+        const { res, body } = await doFetch(`${path}?${params}`, Object.assign({}, opts));
+        if (!res.ok) return { res, spots: null };
+        spots.push(...body.items);
+        cursor = body.next_cursor;
+    } while (cursor);
+    return { res: { ok: true }, spots };
+}
+
 async function loadSpots(){
     console.log('loadSpots() called');
     try {
-        const {res, body} = await doFetch('/spots/');
+        const { res, spots } = await fetchAllSpots('/spots/');
 
         if (!res.ok) {
             console.error('Failed to load spots. Status:', res.status);
             return;
         }
-        renderSpots(body);
+        renderSpots(spots);
     } catch (error) {
         console.error('Error in loadSpots:', error);
     }
@@ -73,12 +88,12 @@ if (dropdownLinks.length > 0) {
             const category = categoryMap[categoryLT];
 
             if (category === 'Slaptos_vietos') {
-                const { res, body } = await doFetch('/spots/category/Slaptos_vietos', { credentials: 'include' });
-                if (res.ok) renderSpots(body);
+                const { res, spots } = await fetchAllSpots('/spots/category/Slaptos_vietos', { credentials: 'include' });
+                if (res.ok) renderSpots(spots);
                 else alert('Please login to view secret spots');
             } else {
-                const { res, body } = await doFetch(`/spots/public/category/${category}`);
-                if (res.ok) renderSpots(body);
+                const { res, spots } = await fetchAllSpots(`/spots/public/category/${category}`);
+                if (res.ok) renderSpots(spots);
             }
         });
     });
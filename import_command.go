@@ -0,0 +1,76 @@
+package main
+
+import (
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/importer"
+	"PilaiteProject/internal/service"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// errImportRejected is returned when the file has row errors, the report says which
+var errImportRejected = errors.New("import rejected, nothing was written")
+
+// runImport handles "import [-format csv|geojson] [-dry-run] FILE", the command line
+// version of POST /spots/import
+func runImport(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "file format, csv or geojson (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "only report errors and duplicates, write nothing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import [-format csv|geojson] [-dry-run] FILE")
+	}
+	path := flags.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if *format == "json" {
+			*format = string(importer.FormatGeoJSON)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := service.NewSpotService(app.conn.Queries).ImportSpots(ctx, importer.Format(*format), file, *dryRun)
+	if err != nil {
+		return err
+	}
+	printImportReport(app.stdout, report)
+	if len(report.Errors) > 0 {
+		return errImportRejected
+	}
+	return nil
+}
+
+func printImportReport(w io.Writer, report *dto.SpotImportReportDTO) {
+	for _, rowErr := range report.Errors {
+		fmt.Fprintf(w, "row %d: %s\n", rowErr.Row, rowErr.Message)
+	}
+	for _, duplicate := range report.Duplicates {
+		if duplicate.SpotID != 0 {
+			fmt.Fprintf(w, "row %d: %q already exists as spot %d, skipped\n", duplicate.Row, duplicate.Name, duplicate.SpotID)
+		} else {
+			fmt.Fprintf(w, "row %d: %q repeats row %d, skipped\n", duplicate.Row, duplicate.Name, duplicate.DuplicateOfRow)
+		}
+	}
+
+	verb := "imported"
+	if report.DryRun || len(report.Errors) > 0 {
+		verb = "would import"
+	}
+	fmt.Fprintf(w, "%d rows: %s %d spots, %d duplicates, %d errors\n",
+		report.Rows, verb, report.Imported, len(report.Duplicates), len(report.Errors))
+}
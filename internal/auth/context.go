@@ -0,0 +1,45 @@
+package auth
+
+import "context"
+
+// contextKey is unexported so no other package can collide with these keys
+type contextKey string
+
+// Keys under which AuthMiddleware stores the logged in user
+const (
+	UserIDKey    contextKey = "userID"
+	UserRoleKey  contextKey = "userRole"
+	UserEmailKey contextKey = "userEmail"
+)
+
+// WithUser returns a context carrying the user info from the session
+func WithUser(ctx context.Context, userID int, role, email string) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, userID)
+	ctx = context.WithValue(ctx, UserRoleKey, role)
+	ctx = context.WithValue(ctx, UserEmailKey, email)
+	return ctx
+}
+
+// UserID retrieves the user ID from the request context
+func UserID(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(UserIDKey).(int)
+	return userID, ok && userID != 0
+}
+
+// UserRole retrieves the user role from the request context
+func UserRole(ctx context.Context) string {
+	role, _ := ctx.Value(UserRoleKey).(string)
+	return role
+}
+
+// IsAuthenticated reports whether the request belongs to a logged in user.
+// Logged in users may see secret spots, anonymous visitors may not.
+func IsAuthenticated(ctx context.Context) bool {
+	_, ok := UserID(ctx)
+	return ok
+}
+
+// IsAdmin reports whether the request belongs to an admin
+func IsAdmin(ctx context.Context) bool {
+	return IsAuthenticated(ctx) && UserRole(ctx) == "admin"
+}
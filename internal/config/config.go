@@ -0,0 +1,269 @@
+// Package config loads the settings shared by every command. Values come from, in
+// increasing priority: the defaults, an optional KEY=VALUE file (.env by default),
+// environment variables and command line flags.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// ErrInvalid wraps every error caused by bad flags or settings
+var ErrInvalid = errors.New("invalid configuration")
+
+// defaultFile is read when it exists, a file named by CONFIG_FILE or -config must exist
+const defaultFile = ".env"
+
+type Config struct {
+	Database DatabaseConfig
+	Server   ServerConfig
+	// MigrateOnStart applies pending migrations before the server starts
+	MigrateOnStart bool
+	// LogLevel is the lowest level that is logged
+	LogLevel slog.Level
+}
+
+type DatabaseConfig struct {
+	// URL is a complete connection string, when set the DB_* parts are ignored
+	URL      string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	// SSLMode overrides the sslmode of URL, the DSN built from the parts defaults to disable
+	SSLMode string
+	// MaxConns and MinConns size the pool, 0 keeps the pgxpool defaults
+	MaxConns int32
+	MinConns int32
+}
+
+type ServerConfig struct {
+	Host string
+	Port string
+	// StaticDir holds index.html and the static/ assets of the frontend
+	StaticDir string
+	// UploadDir is where uploaded spot photos are stored
+	UploadDir string
+	// KeepImageMetadata keeps the metadata stripped from uploads in an admin only column
+	KeepImageMetadata bool
+	// LocalesDir optionally holds <language>.json message catalogs that override or
+	// extend the ones built into the binary
+	LocalesDir string
+	// CORSOrigins are the origins allowed to make credentialed requests, they may
+	// contain a * wildcard
+	CORSOrigins []string
+	Session     SessionConfig
+	Metrics     MetricsConfig
+	// DrainDelay keeps serving after shutdown starts, with /readyz answering 503, so
+	// load balancers stop sending traffic before the listener closes
+	DrainDelay time.Duration
+}
+
+type SessionConfig struct {
+	Lifetime time.Duration
+	// CookieSecure only sends the session cookie over HTTPS, turn it on in production
+	CookieSecure bool
+}
+
+// MetricsConfig decides where /metrics is served, it is off unless one is set
+type MetricsConfig struct {
+	// Addr serves /metrics on a separate listener, e.g. 127.0.0.1:9090
+	Addr string
+	// Token serves /metrics on the main listener to requests with this bearer token
+	Token string
+}
+
+// Addr is the address the HTTP server listens on
+func (c ServerConfig) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// Defaults is the configuration used for local development
+func Defaults() Config {
+	return Config{
+		Server: ServerConfig{
+			Host:        "localhost",
+			Port:        "8080",
+			StaticDir:   "./frontend",
+			UploadDir:   "./uploads",
+			CORSOrigins: []string{"https://*", "http://*"},
+			Session: SessionConfig{
+				Lifetime: 24 * time.Hour,
+			},
+		},
+	}
+}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// DSN is the connection string handed to pgx
+func (c DatabaseConfig) DSN() string {
+	if c.URL == "" {
+		sslMode := c.SSLMode
+		if sslMode == "" {
+			sslMode = "disable"
+		}
+		return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			c.Host, c.Port, c.User, c.Password, c.Name, sslMode,
+		)
+	}
+	if c.SSLMode == "" {
+		return c.URL
+	}
+
+	if u, err := url.Parse(c.URL); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		query := u.Query()
+		query.Set("sslmode", c.SSLMode)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+	// Keyword/value form, the last setting of a keyword wins
+	return c.URL + " sslmode=" + c.SSLMode
+}
+
+// Load builds the configuration from args and the environment. Flags come before the
+// command name, the remaining arguments are returned. All invalid settings are
+// reported together.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, []string, error) {
+	flags, values := newFlagSet()
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return Config{}, nil, err
+		}
+		return Config{}, nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	var fromFlags []setting
+	flags.Visit(func(f *flag.Flag) {
+		if s, ok := settingByFlag(f.Name); ok {
+			fromFlags = append(fromFlags, s)
+		}
+	})
+
+	file, err := readFile(*values["config"], lookupEnv)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	cfg := Defaults()
+	var errs []error
+	for _, s := range settings {
+		value, ok := lookupEnv(s.env)
+		if !ok {
+			value, ok = file[s.env]
+		}
+		if !ok {
+			continue
+		}
+		if err := s.set(&cfg, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.env, err))
+		}
+	}
+	for _, s := range fromFlags {
+		if err := s.set(&cfg, *values[s.flag]); err != nil {
+			errs = append(errs, fmt.Errorf("-%s: %w", s.flag, err))
+		}
+	}
+
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return Config{}, nil, fmt.Errorf("%w:\n%w", ErrInvalid, errors.Join(errs...))
+	}
+	return cfg, flags.Args(), nil
+}
+
+// PrintFlags writes the flags accepted by Load together with their variables
+func PrintFlags(w io.Writer) {
+	flags, _ := newFlagSet()
+	flags.SetOutput(w)
+	flags.PrintDefaults()
+}
+
+func newFlagSet() (*flag.FlagSet, map[string]*string) {
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	values := map[string]*string{
+		"config": flags.String("config", "", "KEY=VALUE file to read, defaults to "+defaultFile+" (CONFIG_FILE)"),
+	}
+	for _, s := range settings {
+		if s.flag != "" {
+			values[s.flag] = flags.String(s.flag, "", s.usage+" ("+s.env+")")
+		}
+	}
+	return flags, values
+}
+
+// readFile reads the KEY=VALUE file, only the default one may be missing
+func readFile(name string, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	if name == "" {
+		name, _ = lookupEnv("CONFIG_FILE")
+	}
+	optional := name == ""
+	if optional {
+		name = defaultFile
+	}
+
+	values, err := godotenv.Read(name)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return values, nil
+}
+
+func (c Config) validate() []error {
+	var errs []error
+
+	if c.Database.URL == "" && (c.Database.Host == "" || c.Database.User == "" || c.Database.Name == "") {
+		errs = append(errs, errors.New("DATABASE_URL or DB_HOST, DB_USER and DB_NAME must be set"))
+	}
+	if c.Database.SSLMode != "" && !slices.Contains(sslModes, c.Database.SSLMode) {
+		errs = append(errs, fmt.Errorf("DB_SSLMODE: must be one of %s", strings.Join(sslModes, ", ")))
+	}
+	if c.Database.MaxConns > 0 && c.Database.MinConns > c.Database.MaxConns {
+		errs = append(errs, errors.New("DB_MIN_CONNS: must not be greater than DB_MAX_CONNS"))
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT: %q is not a port number", c.Server.Port))
+	}
+	if c.Server.StaticDir == "" {
+		errs = append(errs, errors.New("STATIC_DIR: must not be empty"))
+	}
+	if c.Server.UploadDir == "" {
+		errs = append(errs, errors.New("UPLOAD_DIR: must not be empty"))
+	}
+	if c.Server.Session.Lifetime <= 0 {
+		errs = append(errs, errors.New("SESSION_LIFETIME: must be positive"))
+	}
+	if c.Server.DrainDelay < 0 {
+		errs = append(errs, errors.New("DRAIN_DELAY: must not be negative"))
+	}
+	if c.Server.Metrics.Addr != "" {
+		if _, port, err := net.SplitHostPort(c.Server.Metrics.Addr); err != nil || port == "" {
+			errs = append(errs, fmt.Errorf("METRICS_ADDR: %q is not a host:port address", c.Server.Metrics.Addr))
+		}
+	}
+	for _, origin := range c.Server.CORSOrigins {
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("CORS_ORIGINS: %q is not an http(s) origin", origin))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return name
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	file := writeFile(t, "DATABASE_URL=postgres://file/db\nPORT=7000\nHOST=file-host\nSESSION_LIFETIME=1h\n")
+
+	cfg, args, err := Load(
+		[]string{"-config", file, "-port", "9000", "serve", "-migrate"},
+		env(map[string]string{"PORT": "8000", "HOST": "env-host"}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Server.Port != "9000" || cfg.Server.Host != "env-host" || cfg.Server.Session.Lifetime != time.Hour {
+		t.Fatalf("unexpected server config: %+v", cfg.Server)
+	}
+	if cfg.Database.URL != "postgres://file/db" {
+		t.Fatalf("expected the file to set the database url, got %q", cfg.Database.URL)
+	}
+	if len(args) != 2 || args[0] != "serve" {
+		t.Fatalf("expected the command to be left over, got %v", args)
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Chdir(t.TempDir()) // no .env
+
+	cfg, _, err := Load(nil, env(map[string]string{"DATABASE_URL": "postgres://localhost/pilaite"}))
+	if err != nil {
+		t.Fatalf("a missing .env should not be an error: %v", err)
+	}
+	if cfg.Server.Addr() != "localhost:8080" || cfg.Server.Session.CookieSecure {
+		t.Fatalf("unexpected defaults: %+v", cfg.Server)
+	}
+	if len(cfg.Server.CORSOrigins) != 2 || cfg.Server.StaticDir != "./frontend" {
+		t.Fatalf("unexpected defaults: %+v", cfg.Server)
+	}
+}
+
+func TestLoad_MissingExplicitFile(t *testing.T) {
+	_, _, err := Load(nil, env(map[string]string{"CONFIG_FILE": filepath.Join(t.TempDir(), "missing.env")}))
+	if err == nil {
+		t.Fatalf("expected an error for a missing config file")
+	}
+}
+
+func TestLoad_ReportsEveryInvalidSetting(t *testing.T) {
+	_, _, err := Load([]string{"-cookie-secure", "maybe"}, env(map[string]string{
+		"CONFIG_FILE":      writeFile(t, ""),
+		"PORT":             "http",
+		"DB_SSLMODE":       "always",
+		"DB_MAX_CONNS":     "2",
+		"DB_MIN_CONNS":     "5",
+		"SESSION_LIFETIME": "1 day",
+		"CORS_ORIGINS":     "https://example.com, example.org",
+		"LOG_LEVEL":        "loud",
+		"METRICS_ADDR":     "9090",
+		"DRAIN_DELAY":      "-1s",
+	}))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	for _, want := range []string{
+		"-cookie-secure", "PORT", "DB_SSLMODE", "DB_MIN_CONNS", "SESSION_LIFETIME", "LOG_LEVEL", "METRICS_ADDR", "DRAIN_DELAY",
+		`"example.org"`, "DATABASE_URL or DB_HOST",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "https://example.com") {
+		t.Errorf("valid origin reported:\n%v", err)
+	}
+}
+
+func TestDatabaseConfig_DSN(t *testing.T) {
+	tests := []struct {
+		name   string
+		config DatabaseConfig
+		want   string
+	}{
+		{
+			name:   "parts default to sslmode disable",
+			config: DatabaseConfig{Host: "db", Port: "5432", User: "u", Password: "p", Name: "pilaite"},
+			want:   "host=db port=5432 user=u password=p dbname=pilaite sslmode=disable",
+		},
+		{
+			name:   "url is kept as is",
+			config: DatabaseConfig{URL: "postgres://u@db/pilaite?sslmode=require", Host: "ignored"},
+			want:   "postgres://u@db/pilaite?sslmode=require",
+		},
+		{
+			name:   "sslmode overrides the url",
+			config: DatabaseConfig{URL: "postgres://u@db/pilaite?sslmode=disable", SSLMode: "verify-full"},
+			want:   "postgres://u@db/pilaite?sslmode=verify-full",
+		},
+		{
+			name:   "sslmode appended to keyword form",
+			config: DatabaseConfig{URL: "host=db dbname=pilaite", SSLMode: "require"},
+			want:   "host=db dbname=pilaite sslmode=require",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.DSN(); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
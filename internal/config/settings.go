@@ -0,0 +1,120 @@
+package config
+
+import (
+	"PilaiteProject/internal/logging"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting maps one environment variable, and optionally a flag, onto a Config field
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{"HOST", "host", "interface to listen on", stringField(func(c *Config) *string { return &c.Server.Host })},
+	{"PORT", "port", "port to listen on", stringField(func(c *Config) *string { return &c.Server.Port })},
+	{"STATIC_DIR", "static-dir", "directory with index.html and static/", stringField(func(c *Config) *string { return &c.Server.StaticDir })},
+	{"UPLOAD_DIR", "upload-dir", "directory for uploaded photos", stringField(func(c *Config) *string { return &c.Server.UploadDir })},
+	{"LOCALES_DIR", "locales-dir", "directory with message catalogs overriding the built-in ones", stringField(func(c *Config) *string { return &c.Server.LocalesDir })},
+	{"KEEP_IMAGE_METADATA", "keep-image-metadata", "keep the metadata stripped from uploads", boolField(func(c *Config) *bool { return &c.Server.KeepImageMetadata })},
+	{"CORS_ORIGINS", "cors-origins", "comma separated origins allowed by CORS", listField(func(c *Config) *[]string { return &c.Server.CORSOrigins })},
+	{"SESSION_LIFETIME", "session-lifetime", "how long a login lasts, e.g. 24h", durationField(func(c *Config) *time.Duration { return &c.Server.Session.Lifetime })},
+	{"COOKIE_SECURE", "cookie-secure", "only send the session cookie over HTTPS", boolField(func(c *Config) *bool { return &c.Server.Session.CookieSecure })},
+	{"DRAIN_DELAY", "drain-delay", "how long /readyz fails before the listener closes on shutdown, e.g. 5s", durationField(func(c *Config) *time.Duration { return &c.Server.DrainDelay })},
+	{"METRICS_ADDR", "metrics-addr", "separate listener for /metrics, e.g. 127.0.0.1:9090", stringField(func(c *Config) *string { return &c.Server.Metrics.Addr })},
+	// No flag, command lines are visible to every user of the machine
+	{"METRICS_TOKEN", "", "", stringField(func(c *Config) *string { return &c.Server.Metrics.Token })},
+
+	{"DATABASE_URL", "database-url", "database connection string, replaces the DB_* parts", stringField(func(c *Config) *string { return &c.Database.URL })},
+	{"DB_HOST", "", "", stringField(func(c *Config) *string { return &c.Database.Host })},
+	{"DB_PORT", "", "", stringField(func(c *Config) *string { return &c.Database.Port })},
+	{"DB_USER", "", "", stringField(func(c *Config) *string { return &c.Database.User })},
+	{"DB_PASS", "", "", stringField(func(c *Config) *string { return &c.Database.Password })},
+	{"DB_NAME", "", "", stringField(func(c *Config) *string { return &c.Database.Name })},
+	{"DB_SSLMODE", "db-sslmode", "sslmode of the database connection", stringField(func(c *Config) *string { return &c.Database.SSLMode })},
+	{"DB_MAX_CONNS", "db-max-conns", "maximum size of the connection pool", int32Field(func(c *Config) *int32 { return &c.Database.MaxConns })},
+	{"DB_MIN_CONNS", "db-min-conns", "connections the pool keeps open", int32Field(func(c *Config) *int32 { return &c.Database.MinConns })},
+
+	{"LOG_LEVEL", "log-level", "lowest level logged: debug, info, warn or error", levelField(func(c *Config) *slog.Level { return &c.LogLevel })},
+	{"MIGRATE_ON_START", "migrate-on-start", "apply pending migrations before serving", boolField(func(c *Config) *bool { return &c.MigrateOnStart })},
+}
+
+func settingByFlag(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.flag == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func stringField(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		*field(c) = strings.TrimSpace(value)
+		return nil
+	}
+}
+
+func boolField(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+func int32Field(field func(*Config) *int32) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil || n < 0 {
+			return errors.New("must be a non-negative number")
+		}
+		*field(c) = int32(n)
+		return nil
+	}
+}
+
+func durationField(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("must be a duration such as 30m or 24h")
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+func levelField(field func(*Config) *slog.Level) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		level, err := logging.ParseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("must be debug, info, warn or error")
+		}
+		*field(c) = level
+		return nil
+	}
+}
+
+func listField(field func(*Config) *[]string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*field(c) = list
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+)
+
+// This file is not generated. sqlc can only collect :many results into a slice,
+// the exports stream every spot and must not hold the whole table in memory.
+
+// ExportSpotsEach runs the ExportSpots query and calls fn for every row as it is read.
+// An error from fn stops the iteration and is returned as is.
+func (q *Queries) ExportSpotsEach(ctx context.Context, arg ExportSpotsParams, fn func(ExportSpotsRow) error) error {
+	rows, err := q.db.Query(ctx, exportSpots,
+		arg.IncludeSecret,
+		arg.Categories,
+		arg.FilterBbox,
+		arg.MinLat,
+		arg.MaxLat,
+		arg.MinLng,
+		arg.MaxLng,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportSpotsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Category,
+			&i.Description,
+			&i.Address,
+			&i.Latitude,
+			&i.Longitude,
+			&i.ImageUrl,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
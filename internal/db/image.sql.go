@@ -9,8 +9,62 @@ import (
 	"context"
 )
 
+const deleteImage = `-- name: DeleteImage :one
+DELETE FROM image WHERE id = $1 AND spot_id = $2
+RETURNING id, url, spot_id, thumbnail_url, original_metadata
+`
+
+type DeleteImageParams struct {
+	ID     int64
+	SpotID int64
+}
+
+func (q *Queries) DeleteImage(ctx context.Context, arg DeleteImageParams) (Image, error) {
+	row := q.db.QueryRow(ctx, deleteImage, arg.ID, arg.SpotID)
+	var i Image
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.SpotID,
+		&i.ThumbnailUrl,
+		&i.OriginalMetadata,
+	)
+	return i, err
+}
+
+const deleteImagesBySpotID = `-- name: DeleteImagesBySpotID :many
+DELETE FROM image WHERE spot_id = $1
+RETURNING id, url, spot_id, thumbnail_url, original_metadata
+`
+
+func (q *Queries) DeleteImagesBySpotID(ctx context.Context, spotID int64) ([]Image, error) {
+	rows, err := q.db.Query(ctx, deleteImagesBySpotID, spotID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Image
+	for rows.Next() {
+		var i Image
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.SpotID,
+			&i.ThumbnailUrl,
+			&i.OriginalMetadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllImages = `-- name: GetAllImages :many
-SELECT id, url, spot_id FROM image
+SELECT id, url, spot_id, thumbnail_url, original_metadata FROM image
 `
 
 func (q *Queries) GetAllImages(ctx context.Context) ([]Image, error) {
@@ -22,7 +76,13 @@ func (q *Queries) GetAllImages(ctx context.Context) ([]Image, error) {
 	var items []Image
 	for rows.Next() {
 		var i Image
-		if err := rows.Scan(&i.ID, &i.Url, &i.SpotID); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.SpotID,
+			&i.ThumbnailUrl,
+			&i.OriginalMetadata,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -34,32 +94,81 @@ func (q *Queries) GetAllImages(ctx context.Context) ([]Image, error) {
 }
 
 const getImageByID = `-- name: GetImageByID :one
-SELECT id, url, spot_id FROM image WHERE id = $1
+SELECT id, url, spot_id, thumbnail_url, original_metadata FROM image WHERE id = $1
 `
 
 func (q *Queries) GetImageByID(ctx context.Context, id int64) (Image, error) {
 	row := q.db.QueryRow(ctx, getImageByID, id)
 	var i Image
-	err := row.Scan(&i.ID, &i.Url, &i.SpotID)
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.SpotID,
+		&i.ThumbnailUrl,
+		&i.OriginalMetadata,
+	)
 	return i, err
 }
 
+const getImagesBySpotID = `-- name: GetImagesBySpotID :many
+SELECT id, url, spot_id, thumbnail_url, original_metadata FROM image WHERE spot_id = $1 ORDER BY id
+`
+
+func (q *Queries) GetImagesBySpotID(ctx context.Context, spotID int64) ([]Image, error) {
+	rows, err := q.db.Query(ctx, getImagesBySpotID, spotID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Image
+	for rows.Next() {
+		var i Image
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.SpotID,
+			&i.ThumbnailUrl,
+			&i.OriginalMetadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertImage = `-- name: InsertImage :one
 INSERT INTO image(
-    url, spot_id
+    url, thumbnail_url, spot_id, original_metadata
 ) VALUES (
-             $1, $2
-         )RETURNING id, url, spot_id
+             $1, $2, $3, $4
+         )RETURNING id, url, spot_id, thumbnail_url, original_metadata
 `
 
 type InsertImageParams struct {
-	Url    string
-	SpotID int64
+	Url              string
+	ThumbnailUrl     string
+	SpotID           int64
+	OriginalMetadata []byte
 }
 
 func (q *Queries) InsertImage(ctx context.Context, arg InsertImageParams) (Image, error) {
-	row := q.db.QueryRow(ctx, insertImage, arg.Url, arg.SpotID)
+	row := q.db.QueryRow(ctx, insertImage,
+		arg.Url,
+		arg.ThumbnailUrl,
+		arg.SpotID,
+		arg.OriginalMetadata,
+	)
 	var i Image
-	err := row.Scan(&i.ID, &i.Url, &i.SpotID)
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.SpotID,
+		&i.ThumbnailUrl,
+		&i.OriginalMetadata,
+	)
 	return i, err
 }
@@ -9,6 +9,15 @@ import (
 	"context"
 )
 
+const deleteLocation = `-- name: DeleteLocation :exec
+DELETE FROM location WHERE id = $1
+`
+
+func (q *Queries) DeleteLocation(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteLocation, id)
+	return err
+}
+
 const getAllLocations = `-- name: GetAllLocations :many
 SELECT id, address, latitude, longitude FROM location
 `
@@ -79,3 +88,36 @@ func (q *Queries) InsertLocation(ctx context.Context, arg InsertLocationParams)
 	)
 	return i, err
 }
+
+const updateLocation = `-- name: UpdateLocation :one
+UPDATE location
+SET address   = $2,
+    latitude  = $3,
+    longitude = $4
+WHERE id = $1
+RETURNING id, address, latitude, longitude
+`
+
+type UpdateLocationParams struct {
+	ID        int64
+	Address   string
+	Latitude  float64
+	Longitude float64
+}
+
+func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error) {
+	row := q.db.QueryRow(ctx, updateLocation,
+		arg.ID,
+		arg.Address,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
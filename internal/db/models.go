@@ -11,70 +11,213 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type SpotCategory string
+type EquipmentCondition string
 
 const (
-	SpotCategoryGamta              SpotCategory = "Gamta"
-	SpotCategoryLaukoTreniruokliai SpotCategory = "Lauko_treniruokliai"
-	SpotCategorySlaptosVietos      SpotCategory = "Slaptos_vietos"
-	SpotCategoryRestoranai         SpotCategory = "Restoranai"
-	SpotCategoryParduotuves        SpotCategory = "Parduotuves"
+	EquipmentConditionGood   EquipmentCondition = "good"
+	EquipmentConditionWorn   EquipmentCondition = "worn"
+	EquipmentConditionBroken EquipmentCondition = "broken"
 )
 
-func (e *SpotCategory) Scan(src interface{}) error {
+func (e *EquipmentCondition) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = SpotCategory(s)
+		*e = EquipmentCondition(s)
 	case string:
-		*e = SpotCategory(s)
+		*e = EquipmentCondition(s)
 	default:
-		return fmt.Errorf("unsupported scan type for SpotCategory: %T", src)
+		return fmt.Errorf("unsupported scan type for EquipmentCondition: %T", src)
 	}
 	return nil
 }
 
-type NullSpotCategory struct {
-	SpotCategory SpotCategory
-	Valid        bool // Valid is true if SpotCategory is not NULL
+type NullEquipmentCondition struct {
+	EquipmentCondition EquipmentCondition
+	Valid              bool // Valid is true if EquipmentCondition is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullSpotCategory) Scan(value interface{}) error {
+func (ns *NullEquipmentCondition) Scan(value interface{}) error {
 	if value == nil {
-		ns.SpotCategory, ns.Valid = "", false
+		ns.EquipmentCondition, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.SpotCategory.Scan(value)
+	return ns.EquipmentCondition.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullSpotCategory) Value() (driver.Value, error) {
+func (ns NullEquipmentCondition) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.SpotCategory), nil
+	return string(ns.EquipmentCondition), nil
 }
 
-func (e SpotCategory) Valid() bool {
+func (e EquipmentCondition) Valid() bool {
 	switch e {
-	case SpotCategoryGamta,
-		SpotCategoryLaukoTreniruokliai,
-		SpotCategorySlaptosVietos,
-		SpotCategoryRestoranai,
-		SpotCategoryParduotuves:
+	case EquipmentConditionGood,
+		EquipmentConditionWorn,
+		EquipmentConditionBroken:
 		return true
 	}
 	return false
 }
 
-func AllSpotCategoryValues() []SpotCategory {
-	return []SpotCategory{
-		SpotCategoryGamta,
-		SpotCategoryLaukoTreniruokliai,
-		SpotCategorySlaptosVietos,
-		SpotCategoryRestoranai,
-		SpotCategoryParduotuves,
+func AllEquipmentConditionValues() []EquipmentCondition {
+	return []EquipmentCondition{
+		EquipmentConditionGood,
+		EquipmentConditionWorn,
+		EquipmentConditionBroken,
+	}
+}
+
+type EquipmentType string
+
+const (
+	EquipmentTypePullUpBar        EquipmentType = "pull_up_bar"
+	EquipmentTypeParallelBars     EquipmentType = "parallel_bars"
+	EquipmentTypeHorizontalLadder EquipmentType = "horizontal_ladder"
+	EquipmentTypePushUpBars       EquipmentType = "push_up_bars"
+	EquipmentTypeDipStation       EquipmentType = "dip_station"
+	EquipmentTypeSitUpBench       EquipmentType = "sit_up_bench"
+	EquipmentTypeLegPress         EquipmentType = "leg_press"
+	EquipmentTypeChestPress       EquipmentType = "chest_press"
+	EquipmentTypeAirWalker        EquipmentType = "air_walker"
+	EquipmentTypeRowingMachine    EquipmentType = "rowing_machine"
+	EquipmentTypeRings            EquipmentType = "rings"
+	EquipmentTypeOther            EquipmentType = "other"
+)
+
+func (e *EquipmentType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EquipmentType(s)
+	case string:
+		*e = EquipmentType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EquipmentType: %T", src)
+	}
+	return nil
+}
+
+type NullEquipmentType struct {
+	EquipmentType EquipmentType
+	Valid         bool // Valid is true if EquipmentType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEquipmentType) Scan(value interface{}) error {
+	if value == nil {
+		ns.EquipmentType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EquipmentType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEquipmentType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EquipmentType), nil
+}
+
+func (e EquipmentType) Valid() bool {
+	switch e {
+	case EquipmentTypePullUpBar,
+		EquipmentTypeParallelBars,
+		EquipmentTypeHorizontalLadder,
+		EquipmentTypePushUpBars,
+		EquipmentTypeDipStation,
+		EquipmentTypeSitUpBench,
+		EquipmentTypeLegPress,
+		EquipmentTypeChestPress,
+		EquipmentTypeAirWalker,
+		EquipmentTypeRowingMachine,
+		EquipmentTypeRings,
+		EquipmentTypeOther:
+		return true
+	}
+	return false
+}
+
+func AllEquipmentTypeValues() []EquipmentType {
+	return []EquipmentType{
+		EquipmentTypePullUpBar,
+		EquipmentTypeParallelBars,
+		EquipmentTypeHorizontalLadder,
+		EquipmentTypePushUpBars,
+		EquipmentTypeDipStation,
+		EquipmentTypeSitUpBench,
+		EquipmentTypeLegPress,
+		EquipmentTypeChestPress,
+		EquipmentTypeAirWalker,
+		EquipmentTypeRowingMachine,
+		EquipmentTypeRings,
+		EquipmentTypeOther,
+	}
+}
+
+type SubmissionStatus string
+
+const (
+	SubmissionStatusPending  SubmissionStatus = "pending"
+	SubmissionStatusApproved SubmissionStatus = "approved"
+	SubmissionStatusRejected SubmissionStatus = "rejected"
+)
+
+func (e *SubmissionStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SubmissionStatus(s)
+	case string:
+		*e = SubmissionStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SubmissionStatus: %T", src)
+	}
+	return nil
+}
+
+type NullSubmissionStatus struct {
+	SubmissionStatus SubmissionStatus
+	Valid            bool // Valid is true if SubmissionStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSubmissionStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.SubmissionStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SubmissionStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSubmissionStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SubmissionStatus), nil
+}
+
+func (e SubmissionStatus) Valid() bool {
+	switch e {
+	case SubmissionStatusPending,
+		SubmissionStatusApproved,
+		SubmissionStatusRejected:
+		return true
+	}
+	return false
+}
+
+func AllSubmissionStatusValues() []SubmissionStatus {
+	return []SubmissionStatus{
+		SubmissionStatusPending,
+		SubmissionStatusApproved,
+		SubmissionStatusRejected,
 	}
 }
 
@@ -136,10 +279,28 @@ func AllUserRoleValues() []UserRole {
 	}
 }
 
+type Category struct {
+	Slug         string
+	Names        []byte
+	Icon         string
+	SortOrder    int32
+	RequiresAuth bool
+	HasEquipment bool
+	CreatedAt    pgtype.Timestamp
+}
+
+type Favorite struct {
+	UserID    int64
+	SpotID    int64
+	CreatedAt pgtype.Timestamp
+}
+
 type Image struct {
-	ID     int64
-	Url    string
-	SpotID int64
+	ID               int64
+	Url              string
+	SpotID           int64
+	ThumbnailUrl     string
+	OriginalMetadata []byte
 }
 
 type Location struct {
@@ -149,6 +310,33 @@ type Location struct {
 	Longitude float64
 }
 
+type OpeningHour struct {
+	ID      int64
+	SpotID  int64
+	Weekday int16
+	Opens   pgtype.Time
+	Closes  pgtype.Time
+}
+
+type OpeningHoursException struct {
+	ID     int64
+	SpotID int64
+	Date   pgtype.Date
+	Opens  pgtype.Time
+	Closes pgtype.Time
+	Note   string
+}
+
+type Review struct {
+	ID        int64
+	SpotID    int64
+	UserID    int64
+	Rating    int16
+	Comment   string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
 type Session struct {
 	Token  string
 	Data   []byte
@@ -157,10 +345,45 @@ type Session struct {
 
 type Spot struct {
 	ID          int64
-	Category    SpotCategory
+	Category    string
 	Name        string
 	Description string
 	LocationID  int64
+	CreatedAt   pgtype.Timestamp
+}
+
+type SpotEquipment struct {
+	ID        int64
+	SpotID    int64
+	Type      EquipmentType
+	Quantity  int32
+	Condition EquipmentCondition
+	UpdatedAt pgtype.Timestamp
+}
+
+type SpotSubmission struct {
+	ID              int64
+	UserID          int64
+	Category        string
+	Name            string
+	Description     string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	Status          SubmissionStatus
+	RejectionReason string
+	SpotID          pgtype.Int8
+	ReviewedBy      pgtype.Int8
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
+type SubmissionImage struct {
+	ID               int64
+	SubmissionID     int64
+	Url              string
+	ThumbnailUrl     string
+	OriginalMetadata []byte
 }
 
 type User struct {
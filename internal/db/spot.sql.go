@@ -7,10 +7,67 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countSpots = `-- name: CountSpots :one
+SELECT count(*)
+FROM spot s
+         INNER JOIN category c ON s.category = c.slug
+WHERE ($1::boolean OR NOT c.requires_auth)
+  AND (cardinality($2::text[]) = 0 OR s.category = ANY ($2::text[]))
+  AND (NOT $3::boolean OR spot_is_open(s.id, $4::date, $5::time))
+  AND (cardinality($6::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY ($6::text[]) AND e.condition != 'broken'
+  ) = cardinality($6::text[]))
+`
+
+type CountSpotsParams struct {
+	IncludeSecret bool
+	Categories    []string
+	FilterOpen    bool
+	OpenDate      pgtype.Date
+	OpenTime      pgtype.Time
+	Equipment     []string
+}
+
+func (q *Queries) CountSpots(ctx context.Context, arg CountSpotsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSpots,
+		arg.IncludeSecret,
+		arg.Categories,
+		arg.FilterOpen,
+		arg.OpenDate,
+		arg.OpenTime,
+		arg.Equipment,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteSpot = `-- name: DeleteSpot :one
+DELETE FROM spot WHERE id = $1
+RETURNING id, category, name, description, location_id, created_at
+`
+
+func (q *Queries) DeleteSpot(ctx context.Context, id int64) (Spot, error) {
+	row := q.db.QueryRow(ctx, deleteSpot, id)
+	var i Spot
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Name,
+		&i.Description,
+		&i.LocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getAllSpots = `-- name: GetAllSpots :many
-SELECT id, category, name, description, location_id FROM spot
+SELECT id, category, name, description, location_id, created_at FROM spot
 `
 
 func (q *Queries) GetAllSpots(ctx context.Context) ([]Spot, error) {
@@ -28,6 +85,122 @@ func (q *Queries) GetAllSpots(ctx context.Context) ([]Spot, error) {
 			&i.Name,
 			&i.Description,
 			&i.LocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSpotByID = `-- name: GetSpotByID :one
+SELECT id, category, name, description, location_id, created_at FROM spot WHERE id = $1
+`
+
+func (q *Queries) GetSpotByID(ctx context.Context, id int64) (Spot, error) {
+	row := q.db.QueryRow(ctx, getSpotByID, id)
+	var i Spot
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Name,
+		&i.Description,
+		&i.LocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSpotByIDForUpdate = `-- name: GetSpotByIDForUpdate :one
+SELECT id, category, name, description, location_id, created_at FROM spot WHERE id = $1 FOR UPDATE
+`
+
+// Locks the row so concurrent partial updates don't overwrite each other
+func (q *Queries) GetSpotByIDForUpdate(ctx context.Context, id int64) (Spot, error) {
+	row := q.db.QueryRow(ctx, getSpotByIDForUpdate, id)
+	var i Spot
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Name,
+		&i.Description,
+		&i.LocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSpotClustersInBBox = `-- name: GetSpotClustersInBBox :many
+SELECT
+    floor(l.latitude::float8 / $1::float8)::bigint AS cell_y,
+    floor(l.longitude::float8 / $1::float8)::bigint AS cell_x,
+    s.category,
+    count(*)::bigint AS spot_count,
+    avg(l.latitude::float8)::float8 AS avg_latitude,
+    avg(l.longitude::float8)::float8 AS avg_longitude,
+    min(s.id)::bigint AS sample_id
+FROM spot s
+         INNER JOIN location l ON s.location_id = l.id
+         INNER JOIN category c ON s.category = c.slug
+WHERE l.latitude BETWEEN $2 AND $3
+  AND l.longitude BETWEEN $4 AND $5
+  AND ($6::boolean OR NOT c.requires_auth)
+GROUP BY cell_y, cell_x, s.category
+ORDER BY cell_y, cell_x, s.category
+LIMIT $7
+`
+
+type GetSpotClustersInBBoxParams struct {
+	CellSize      float64
+	MinLat        float64
+	MaxLat        float64
+	MinLng        float64
+	MaxLng        float64
+	IncludeSecret bool
+	MaxResults    int32
+}
+
+type GetSpotClustersInBBoxRow struct {
+	CellY        int64
+	CellX        int64
+	Category     string
+	SpotCount    int64
+	AvgLatitude  float64
+	AvgLongitude float64
+	SampleID     int64
+}
+
+// Groups spots into a grid of cell_size degrees, per category so the
+// caller can build the category breakdown of each cell
+func (q *Queries) GetSpotClustersInBBox(ctx context.Context, arg GetSpotClustersInBBoxParams) ([]GetSpotClustersInBBoxRow, error) {
+	rows, err := q.db.Query(ctx, getSpotClustersInBBox,
+		arg.CellSize,
+		arg.MinLat,
+		arg.MaxLat,
+		arg.MinLng,
+		arg.MaxLng,
+		arg.IncludeSecret,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSpotClustersInBBoxRow
+	for rows.Next() {
+		var i GetSpotClustersInBBoxRow
+		if err := rows.Scan(
+			&i.CellY,
+			&i.CellX,
+			&i.Category,
+			&i.SpotCount,
+			&i.AvgLatitude,
+			&i.AvgLongitude,
+			&i.SampleID,
 		); err != nil {
 			return nil, err
 		}
@@ -39,7 +212,7 @@ func (q *Queries) GetAllSpots(ctx context.Context) ([]Spot, error) {
 	return items, nil
 }
 
-const getPublicSpotsByCategoryWithDetails = `-- name: GetPublicSpotsByCategoryWithDetails :many
+const getSpotsInBBox = `-- name: GetSpotsInBBox :many
 SELECT
     s.id,
     s.name,
@@ -47,32 +220,58 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text as image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-WHERE s.category = $1 AND s.category != 'Slaptos_vietos'
+         INNER JOIN category c ON s.category = c.slug
+WHERE l.latitude BETWEEN $1 AND $2
+  AND l.longitude BETWEEN $3 AND $4
+  AND ($5::boolean OR NOT c.requires_auth)
 ORDER BY s.id
+LIMIT $6
 `
 
-type GetPublicSpotsByCategoryWithDetailsRow struct {
-	ID        int64
-	Name      string
-	Category  SpotCategory
-	Address   string
-	Latitude  float64
-	Longitude float64
-	ImageUrl  string
+type GetSpotsInBBoxParams struct {
+	MinLat        float64
+	MaxLat        float64
+	MinLng        float64
+	MaxLng        float64
+	IncludeSecret bool
+	MaxResults    int32
 }
 
-func (q *Queries) GetPublicSpotsByCategoryWithDetails(ctx context.Context, category SpotCategory) ([]GetPublicSpotsByCategoryWithDetailsRow, error) {
-	rows, err := q.db.Query(ctx, getPublicSpotsByCategoryWithDetails, category)
+type GetSpotsInBBoxRow struct {
+	ID              int64
+	Name            string
+	Category        string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	ImageUrl        string
+	ReviewCount     int64
+	AverageRating   float64
+	HasOpeningHours bool
+}
+
+func (q *Queries) GetSpotsInBBox(ctx context.Context, arg GetSpotsInBBoxParams) ([]GetSpotsInBBoxRow, error) {
+	rows, err := q.db.Query(ctx, getSpotsInBBox,
+		arg.MinLat,
+		arg.MaxLat,
+		arg.MinLng,
+		arg.MaxLng,
+		arg.IncludeSecret,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetPublicSpotsByCategoryWithDetailsRow
+	var items []GetSpotsInBBoxRow
 	for rows.Next() {
-		var i GetPublicSpotsByCategoryWithDetailsRow
+		var i GetSpotsInBBoxRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -81,6 +280,9 @@ func (q *Queries) GetPublicSpotsByCategoryWithDetails(ctx context.Context, categ
 			&i.Latitude,
 			&i.Longitude,
 			&i.ImageUrl,
+			&i.ReviewCount,
+			&i.AverageRating,
+			&i.HasOpeningHours,
 		); err != nil {
 			return nil, err
 		}
@@ -92,7 +294,142 @@ func (q *Queries) GetPublicSpotsByCategoryWithDetails(ctx context.Context, categ
 	return items, nil
 }
 
-const getPublicSpotsWithDetails = `-- name: GetPublicSpotsWithDetails :many
+const getSpotsNearby = `-- name: GetSpotsNearby :many
+SELECT id, name, category, address, latitude, longitude, image_url, review_count, average_rating, has_opening_hours, distance_m
+FROM (
+    SELECT
+        s.id,
+        s.name,
+        s.category,
+        l.address,
+        l.latitude::float8 AS latitude,
+        l.longitude::float8 AS longitude,
+        COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+        (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+        (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+        EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+        (2 * 6371000 * asin(sqrt(
+            power(sin(radians(l.latitude::float8 - $1::float8) / 2), 2) +
+            cos(radians($1::float8)) * cos(radians(l.latitude::float8)) *
+            power(sin(radians(l.longitude::float8 - $2::float8) / 2), 2)
+        )))::float8 AS distance_m
+    FROM spot s
+             INNER JOIN location l ON s.location_id = l.id
+             INNER JOIN category c ON s.category = c.slug
+    WHERE l.latitude BETWEEN $3 AND $4
+      AND l.longitude BETWEEN $5 AND $6
+      AND ($7::boolean OR NOT c.requires_auth)
+) AS nearby
+WHERE distance_m <= $8::float8
+ORDER BY distance_m, id
+LIMIT $9
+`
+
+type GetSpotsNearbyParams struct {
+	Lat           float64
+	Lng           float64
+	MinLat        float64
+	MaxLat        float64
+	MinLng        float64
+	MaxLng        float64
+	IncludeSecret bool
+	RadiusM       float64
+	MaxResults    int32
+}
+
+type GetSpotsNearbyRow struct {
+	ID              int64
+	Name            string
+	Category        string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	ImageUrl        string
+	ReviewCount     int64
+	AverageRating   float64
+	HasOpeningHours bool
+	DistanceM       float64
+}
+
+// The bounding box on the raw columns can use location_coordinates_idx,
+// the exact haversine distance is only computed for rows inside the box
+func (q *Queries) GetSpotsNearby(ctx context.Context, arg GetSpotsNearbyParams) ([]GetSpotsNearbyRow, error) {
+	rows, err := q.db.Query(ctx, getSpotsNearby,
+		arg.Lat,
+		arg.Lng,
+		arg.MinLat,
+		arg.MaxLat,
+		arg.MinLng,
+		arg.MaxLng,
+		arg.IncludeSecret,
+		arg.RadiusM,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSpotsNearbyRow
+	for rows.Next() {
+		var i GetSpotsNearbyRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Category,
+			&i.Address,
+			&i.Latitude,
+			&i.Longitude,
+			&i.ImageUrl,
+			&i.ReviewCount,
+			&i.AverageRating,
+			&i.HasOpeningHours,
+			&i.DistanceM,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertSpot = `-- name: InsertSpot :one
+INSERT INTO spot(
+    category, name, description, location_id
+) VALUES (
+             $1, $2, $3, $4
+         ) RETURNING id, category, name, description, location_id, created_at
+`
+
+type InsertSpotParams struct {
+	Category    string
+	Name        string
+	Description string
+	LocationID  int64
+}
+
+func (q *Queries) InsertSpot(ctx context.Context, arg InsertSpotParams) (Spot, error) {
+	row := q.db.QueryRow(ctx, insertSpot,
+		arg.Category,
+		arg.Name,
+		arg.Description,
+		arg.LocationID,
+	)
+	var i Spot
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Name,
+		&i.Description,
+		&i.LocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listSpotsByID = `-- name: ListSpotsByID :many
 SELECT
     s.id,
     s.name,
@@ -100,32 +437,73 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text as image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    s.created_at
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-WHERE s.category != 'Slaptos_vietos'
+         INNER JOIN category c ON s.category = c.slug
+WHERE ($1::boolean OR NOT c.requires_auth)
+  AND (cardinality($2::text[]) = 0 OR s.category = ANY ($2::text[]))
+  AND (NOT $3::boolean OR spot_is_open(s.id, $4::date, $5::time))
+  AND (cardinality($6::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY ($6::text[]) AND e.condition != 'broken'
+  ) = cardinality($6::text[]))
+  AND (NOT $7::boolean OR s.id > $8::bigint)
 ORDER BY s.id
+LIMIT $9
 `
 
-type GetPublicSpotsWithDetailsRow struct {
-	ID        int64
-	Name      string
-	Category  SpotCategory
-	Address   string
-	Latitude  float64
-	Longitude float64
-	ImageUrl  string
+type ListSpotsByIDParams struct {
+	IncludeSecret bool
+	Categories    []string
+	FilterOpen    bool
+	OpenDate      pgtype.Date
+	OpenTime      pgtype.Time
+	Equipment     []string
+	HasCursor     bool
+	CursorID      int64
+	MaxResults    int32
+}
+
+type ListSpotsByIDRow struct {
+	ID              int64
+	Name            string
+	Category        string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	ImageUrl        string
+	ReviewCount     int64
+	AverageRating   float64
+	HasOpeningHours bool
+	CreatedAt       pgtype.Timestamp
 }
 
-func (q *Queries) GetPublicSpotsWithDetails(ctx context.Context) ([]GetPublicSpotsWithDetailsRow, error) {
-	rows, err := q.db.Query(ctx, getPublicSpotsWithDetails)
+// Keyset paginated spot list in id order, the cursor is the last id of the previous page.
+// One query per sort order keeps ORDER BY static, so the matching index is used.
+func (q *Queries) ListSpotsByID(ctx context.Context, arg ListSpotsByIDParams) ([]ListSpotsByIDRow, error) {
+	rows, err := q.db.Query(ctx, listSpotsByID,
+		arg.IncludeSecret,
+		arg.Categories,
+		arg.FilterOpen,
+		arg.OpenDate,
+		arg.OpenTime,
+		arg.Equipment,
+		arg.HasCursor,
+		arg.CursorID,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetPublicSpotsWithDetailsRow
+	var items []ListSpotsByIDRow
 	for rows.Next() {
-		var i GetPublicSpotsWithDetailsRow
+		var i ListSpotsByIDRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -134,6 +512,10 @@ func (q *Queries) GetPublicSpotsWithDetails(ctx context.Context) ([]GetPublicSpo
 			&i.Latitude,
 			&i.Longitude,
 			&i.ImageUrl,
+			&i.ReviewCount,
+			&i.AverageRating,
+			&i.HasOpeningHours,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -145,24 +527,106 @@ func (q *Queries) GetPublicSpotsWithDetails(ctx context.Context) ([]GetPublicSpo
 	return items, nil
 }
 
-const getSpotByID = `-- name: GetSpotByID :one
-SELECT id, category, name, description, location_id FROM spot WHERE id = $1
+const listSpotsByName = `-- name: ListSpotsByName :many
+SELECT
+    s.id,
+    s.name,
+    s.category,
+    l.address,
+    l.latitude,
+    l.longitude,
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    s.created_at
+FROM spot s
+         INNER JOIN location l ON s.location_id = l.id
+         INNER JOIN category c ON s.category = c.slug
+WHERE ($1::boolean OR NOT c.requires_auth)
+  AND (cardinality($2::text[]) = 0 OR s.category = ANY ($2::text[]))
+  AND (NOT $3::boolean OR spot_is_open(s.id, $4::date, $5::time))
+  AND (cardinality($6::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY ($6::text[]) AND e.condition != 'broken'
+  ) = cardinality($6::text[]))
+  AND (NOT $7::boolean OR (s.name, s.id) > ($8::text, $9::bigint))
+ORDER BY s.name, s.id
+LIMIT $10
 `
 
-func (q *Queries) GetSpotByID(ctx context.Context, id int64) (Spot, error) {
-	row := q.db.QueryRow(ctx, getSpotByID, id)
-	var i Spot
-	err := row.Scan(
-		&i.ID,
-		&i.Category,
-		&i.Name,
-		&i.Description,
-		&i.LocationID,
+type ListSpotsByNameParams struct {
+	IncludeSecret bool
+	Categories    []string
+	FilterOpen    bool
+	OpenDate      pgtype.Date
+	OpenTime      pgtype.Time
+	Equipment     []string
+	HasCursor     bool
+	CursorName    string
+	CursorID      int64
+	MaxResults    int32
+}
+
+type ListSpotsByNameRow struct {
+	ID              int64
+	Name            string
+	Category        string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	ImageUrl        string
+	ReviewCount     int64
+	AverageRating   float64
+	HasOpeningHours bool
+	CreatedAt       pgtype.Timestamp
+}
+
+// ListSpotsByID sorted by name, ties are broken by id. Uses spot_name_id_idx.
+func (q *Queries) ListSpotsByName(ctx context.Context, arg ListSpotsByNameParams) ([]ListSpotsByNameRow, error) {
+	rows, err := q.db.Query(ctx, listSpotsByName,
+		arg.IncludeSecret,
+		arg.Categories,
+		arg.FilterOpen,
+		arg.OpenDate,
+		arg.OpenTime,
+		arg.Equipment,
+		arg.HasCursor,
+		arg.CursorName,
+		arg.CursorID,
+		arg.MaxResults,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSpotsByNameRow
+	for rows.Next() {
+		var i ListSpotsByNameRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Category,
+			&i.Address,
+			&i.Latitude,
+			&i.Longitude,
+			&i.ImageUrl,
+			&i.ReviewCount,
+			&i.AverageRating,
+			&i.HasOpeningHours,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getSpotsByCategoryWithDetails = `-- name: GetSpotsByCategoryWithDetails :many
+const listSpotsByNewest = `-- name: ListSpotsByNewest :many
 SELECT
     s.id,
     s.name,
@@ -170,32 +634,74 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    s.created_at
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-WHERE s.category = $1
-ORDER BY s.id
+         INNER JOIN category c ON s.category = c.slug
+WHERE ($1::boolean OR NOT c.requires_auth)
+  AND (cardinality($2::text[]) = 0 OR s.category = ANY ($2::text[]))
+  AND (NOT $3::boolean OR spot_is_open(s.id, $4::date, $5::time))
+  AND (cardinality($6::text[]) = 0 OR (
+      SELECT count(DISTINCT e.type) FROM spot_equipment e
+      WHERE e.spot_id = s.id AND e.type::text = ANY ($6::text[]) AND e.condition != 'broken'
+  ) = cardinality($6::text[]))
+  AND (NOT $7::boolean OR (s.created_at, s.id) < ($8::timestamp, $9::bigint))
+ORDER BY s.created_at DESC, s.id DESC
+LIMIT $10
 `
 
-type GetSpotsByCategoryWithDetailsRow struct {
-	ID        int64
-	Name      string
-	Category  SpotCategory
-	Address   string
-	Latitude  float64
-	Longitude float64
-	ImageUrl  string
+type ListSpotsByNewestParams struct {
+	IncludeSecret   bool
+	Categories      []string
+	FilterOpen      bool
+	OpenDate        pgtype.Date
+	OpenTime        pgtype.Time
+	Equipment       []string
+	HasCursor       bool
+	CursorCreatedAt pgtype.Timestamp
+	CursorID        int64
+	MaxResults      int32
 }
 
-func (q *Queries) GetSpotsByCategoryWithDetails(ctx context.Context, category SpotCategory) ([]GetSpotsByCategoryWithDetailsRow, error) {
-	rows, err := q.db.Query(ctx, getSpotsByCategoryWithDetails, category)
+type ListSpotsByNewestRow struct {
+	ID              int64
+	Name            string
+	Category        string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	ImageUrl        string
+	ReviewCount     int64
+	AverageRating   float64
+	HasOpeningHours bool
+	CreatedAt       pgtype.Timestamp
+}
+
+// ListSpotsByID with the newest spots first, ties are broken by id. Uses spot_created_at_id_idx.
+func (q *Queries) ListSpotsByNewest(ctx context.Context, arg ListSpotsByNewestParams) ([]ListSpotsByNewestRow, error) {
+	rows, err := q.db.Query(ctx, listSpotsByNewest,
+		arg.IncludeSecret,
+		arg.Categories,
+		arg.FilterOpen,
+		arg.OpenDate,
+		arg.OpenTime,
+		arg.Equipment,
+		arg.HasCursor,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetSpotsByCategoryWithDetailsRow
+	var items []ListSpotsByNewestRow
 	for rows.Next() {
-		var i GetSpotsByCategoryWithDetailsRow
+		var i ListSpotsByNewestRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -204,6 +710,10 @@ func (q *Queries) GetSpotsByCategoryWithDetails(ctx context.Context, category Sp
 			&i.Latitude,
 			&i.Longitude,
 			&i.ImageUrl,
+			&i.ReviewCount,
+			&i.AverageRating,
+			&i.HasOpeningHours,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -215,7 +725,7 @@ func (q *Queries) GetSpotsByCategoryWithDetails(ctx context.Context, category Sp
 	return items, nil
 }
 
-const getSpotsWithDetails = `-- name: GetSpotsWithDetails :many
+const searchSpots = `-- name: SearchSpots :many
 SELECT
     s.id,
     s.name,
@@ -223,31 +733,65 @@ SELECT
     l.address,
     l.latitude,
     l.longitude,
-    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text as image_url
+    COALESCE((SELECT url FROM image WHERE spot_id = s.id LIMIT 1), '')::text AS image_url,
+    (SELECT count(*) FROM review WHERE spot_id = s.id)::bigint AS review_count,
+    (SELECT COALESCE(round(avg(rating), 2), 0) FROM review WHERE spot_id = s.id)::float8 AS average_rating,
+    EXISTS (SELECT 1 FROM opening_hours WHERE spot_id = s.id) AS has_opening_hours,
+    ts_rank(
+        setweight(to_tsvector('lithuanian_unaccent', s.name), 'A') ||
+        setweight(to_tsvector('lithuanian_unaccent', s.description), 'B') ||
+        setweight(to_tsvector('lithuanian_unaccent', l.address), 'C'),
+        q.query
+    )::float8 AS rank,
+    ts_headline(
+        'lithuanian_unaccent',
+        s.name || ' - ' || s.description || ' - ' || l.address,
+        q.query,
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=25, MinWords=10'
+    )::text AS snippet
 FROM spot s
          INNER JOIN location l ON s.location_id = l.id
-ORDER BY s.id
+         INNER JOIN category c ON s.category = c.slug
+         CROSS JOIN websearch_to_tsquery('lithuanian_unaccent', $1::text) AS q(query)
+WHERE (to_tsvector('lithuanian_unaccent', s.name || ' ' || s.description) @@ q.query
+    OR to_tsvector('lithuanian_unaccent', l.address) @@ q.query)
+  AND ($2::boolean OR NOT c.requires_auth)
+ORDER BY rank DESC, s.id
+LIMIT $3
 `
 
-type GetSpotsWithDetailsRow struct {
-	ID        int64
-	Name      string
-	Category  SpotCategory
-	Address   string
-	Latitude  float64
-	Longitude float64
-	ImageUrl  string
+type SearchSpotsParams struct {
+	Query         string
+	IncludeSecret bool
+	MaxResults    int32
+}
+
+type SearchSpotsRow struct {
+	ID              int64
+	Name            string
+	Category        string
+	Address         string
+	Latitude        float64
+	Longitude       float64
+	ImageUrl        string
+	ReviewCount     int64
+	AverageRating   float64
+	HasOpeningHours bool
+	Rank            float64
+	Snippet         string
 }
 
-func (q *Queries) GetSpotsWithDetails(ctx context.Context) ([]GetSpotsWithDetailsRow, error) {
-	rows, err := q.db.Query(ctx, getSpotsWithDetails)
+// Matches name, description and address with diacritics folded, best matches first.
+// The snippet marks matched words with \x02 and \x03 so they can be escaped safely.
+func (q *Queries) SearchSpots(ctx context.Context, arg SearchSpotsParams) ([]SearchSpotsRow, error) {
+	rows, err := q.db.Query(ctx, searchSpots, arg.Query, arg.IncludeSecret, arg.MaxResults)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetSpotsWithDetailsRow
+	var items []SearchSpotsRow
 	for rows.Next() {
-		var i GetSpotsWithDetailsRow
+		var i SearchSpotsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -256,6 +800,11 @@ func (q *Queries) GetSpotsWithDetails(ctx context.Context) ([]GetSpotsWithDetail
 			&i.Latitude,
 			&i.Longitude,
 			&i.ImageUrl,
+			&i.ReviewCount,
+			&i.AverageRating,
+			&i.HasOpeningHours,
+			&i.Rank,
+			&i.Snippet,
 		); err != nil {
 			return nil, err
 		}
@@ -267,27 +816,28 @@ func (q *Queries) GetSpotsWithDetails(ctx context.Context) ([]GetSpotsWithDetail
 	return items, nil
 }
 
-const insertSpot = `-- name: InsertSpot :one
-INSERT INTO spot(
-    category, name, description, location_id
-) VALUES (
-             $1, $2, $3, $4
-         ) RETURNING id, category, name, description, location_id
+const updateSpot = `-- name: UpdateSpot :one
+UPDATE spot
+SET category    = $2,
+    name        = $3,
+    description = $4
+WHERE id = $1
+RETURNING id, category, name, description, location_id, created_at
 `
 
-type InsertSpotParams struct {
-	Category    SpotCategory
+type UpdateSpotParams struct {
+	ID          int64
+	Category    string
 	Name        string
 	Description string
-	LocationID  int64
 }
 
-func (q *Queries) InsertSpot(ctx context.Context, arg InsertSpotParams) (Spot, error) {
-	row := q.db.QueryRow(ctx, insertSpot,
+func (q *Queries) UpdateSpot(ctx context.Context, arg UpdateSpotParams) (Spot, error) {
+	row := q.db.QueryRow(ctx, updateSpot,
+		arg.ID,
 		arg.Category,
 		arg.Name,
 		arg.Description,
-		arg.LocationID,
 	)
 	var i Spot
 	err := row.Scan(
@@ -296,6 +846,7 @@ func (q *Queries) InsertSpot(ctx context.Context, arg InsertSpotParams) (Spot, e
 		&i.Name,
 		&i.Description,
 		&i.LocationID,
+		&i.CreatedAt,
 	)
 	return i, err
 }
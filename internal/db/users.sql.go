@@ -103,3 +103,25 @@ func (q *Queries) InsertUser(ctx context.Context, arg InsertUserParams) (User, e
 	)
 	return i, err
 }
+
+const promoteUserToAdmin = `-- name: PromoteUserToAdmin :one
+UPDATE users
+SET role       = 'admin',
+    updated_at = CURRENT_TIMESTAMP
+WHERE email = $1
+RETURNING id, email, password, role, created_at, updated_at
+`
+
+func (q *Queries) PromoteUserToAdmin(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, promoteUserToAdmin, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
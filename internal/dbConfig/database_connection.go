@@ -1,16 +1,14 @@
 package dbConfig
 
 import (
-	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/config"
 	"PilaiteProject/internal/wrapper"
 	"context"
 	_ "database/sql"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
@@ -19,52 +17,32 @@ type Connection struct {
 	Pool    *pgxpool.Pool
 }
 
-func loadEnvFile(filename string) error {
-	err := godotenv.Load(filename)
+// Connect opens the pool and checks that the database answers
+func Connect(ctx context.Context, cfg config.DatabaseConfig) (*Connection, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN())
 	if err != nil {
-		log.Fatal("Error loading .env file", err)
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
 	}
-	return err
-}
-
-func getDbURL() (databaseUrl string) {
-	var dbHost = os.Getenv("DB_HOST")
-	//fmt.Println("DB Host: " + dbHost)
-	var dbPort = os.Getenv("DB_PORT")
-	//fmt.Println("DB Port: " + dbPort)
-	var dbUser = os.Getenv("DB_USER")
-	//fmt.Println("User= " + dbUser)
-	var dbPass = os.Getenv("DB_PASS")
-	//fmt.Println("password= " + dbPass)
-	var dbName = os.Getenv("DB_NAME")
-	//fmt.Println("DB Name= " + dbName)
-
-	databaseUrl = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost, dbPort, dbUser, dbPass, dbName,
-	)
-
-	return databaseUrl
-}
-
-func ConnectDb(ctx context.Context) (*Connection, error) {
-	err1 := loadEnvFile(".env")
-	if err1 != nil {
-		log.Println("Failed to load .env")
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = cfg.MinConns
 	}
 
-	dbConn, err2 := pgxpool.New(ctx, getDbURL())
-	if err2 != nil {
-		log.Fatal(err2)
+	dbConn, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
 
-	err3 := dbConn.Ping(ctx)
-	if err3 != nil {
-		log.Fatal("Cannot ping db", err3)
+	if err := dbConn.Ping(ctx); err != nil {
+		dbConn.Close()
+		return nil, fmt.Errorf("cannot ping db: %w", err)
 	}
-	fmt.Println("Connected to database successfully")
+	slog.Info("connected to database", "max_conns", poolConfig.MaxConns)
 
 	connection := &Connection{
-		Queries: wrapper.NewAppQueries(db.New(dbConn)),
+		Queries: wrapper.NewAppQueries(dbConn),
 		Pool:    dbConn,
 	}
 	return connection, nil
@@ -0,0 +1,12 @@
+package dto
+
+// CategoryDTO is a spot category, Name is the display name in the language of the request
+type CategoryDTO struct {
+	Slug         string            `json:"slug"`
+	Name         string            `json:"name"`
+	Names        map[string]string `json:"names"`
+	Icon         string            `json:"icon"`
+	SortOrder    int32             `json:"sort_order"`
+	RequiresAuth bool              `json:"requires_auth"`
+	HasEquipment bool              `json:"has_equipment"`
+}
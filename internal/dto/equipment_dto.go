@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// EquipmentDTO is one kind of equipment at an outdoor gym spot
+type EquipmentDTO struct {
+	Type      string    `json:"type"`
+	Quantity  int32     `json:"quantity"`
+	Condition string    `json:"condition"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+// SpotExportDTO is one spot in a map file export (GeoJSON, GPX, KML)
+type SpotExportDTO struct {
+	ID           int64
+	Name         string
+	Category     string
+	CategoryName string
+	Description  string
+	Address      string
+	Latitude     float64
+	Longitude    float64
+	// ImageURL is the first photo of the spot, empty when it has none
+	ImageURL string
+}
+
+// SpotImportReportDTO describes a bulk import. In a dry run, and whenever a row has an
+// error, nothing is written and Imported is the number of spots that would be added.
+type SpotImportReportDTO struct {
+	DryRun     bool                     `json:"dry_run"`
+	Rows       int                      `json:"rows"`
+	Imported   int                      `json:"imported"`
+	Errors     []SpotImportErrorDTO     `json:"errors"`
+	Duplicates []SpotImportDuplicateDTO `json:"duplicates"`
+}
+
+type SpotImportErrorDTO struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// SpotImportDuplicateDTO is a row that is skipped because the spot already exists,
+// either in the database (SpotID) or earlier in the same file (DuplicateOfRow)
+type SpotImportDuplicateDTO struct {
+	Row            int    `json:"row"`
+	Name           string `json:"name"`
+	SpotID         int64  `json:"spot_id,omitempty"`
+	DuplicateOfRow int    `json:"duplicate_of_row,omitempty"`
+}
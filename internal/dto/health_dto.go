@@ -0,0 +1,14 @@
+package dto
+
+// HealthDTO answers /healthz and /readyz, Checks is only filled by /readyz
+type HealthDTO struct {
+	Status     string                    `json:"status"`
+	DurationMs float64                   `json:"duration_ms"`
+	Checks     map[string]HealthCheckDTO `json:"checks,omitempty"`
+}
+
+type HealthCheckDTO struct {
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
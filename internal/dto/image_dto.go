@@ -0,0 +1,8 @@
+package dto
+
+type ImageDTO struct {
+	ID           int64  `json:"id"`
+	SpotID       int64  `json:"spot_id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
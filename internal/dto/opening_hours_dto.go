@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// OpeningStatusDTO tells whether a spot is open right now. NextChange is when it
+// closes or opens next, null when that is not known within the coming two weeks.
+type OpeningStatusDTO struct {
+	IsOpen     bool       `json:"is_open"`
+	NextChange *time.Time `json:"next_change"`
+}
+
+// OpeningHoursDTO is the full schedule of a spot, all times are local to Timezone
+type OpeningHoursDTO struct {
+	SpotID     int64                      `json:"spot_id"`
+	Timezone   string                     `json:"timezone"`
+	Weekly     []OpeningShiftDTO          `json:"weekly"`
+	Exceptions []OpeningHoursExceptionDTO `json:"exceptions"`
+	// Status is null for spots without opening hours
+	Status *OpeningStatusDTO `json:"status"`
+}
+
+// OpeningShiftDTO is one shift, weekday 1 is Monday. A closing time that is not after
+// the opening time belongs to the next day, "24:00" closes at midnight.
+type OpeningShiftDTO struct {
+	Weekday int    `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+// OpeningHoursExceptionDTO replaces the weekly shifts of one date, without times the spot is closed that day
+type OpeningHoursExceptionDTO struct {
+	Date   string `json:"date"`
+	Opens  string `json:"opens,omitempty"`
+	Closes string `json:"closes,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type ReviewDTO struct {
+	ID        int64     `json:"id"`
+	SpotID    int64     `json:"spot_id"`
+	UserID    int64     `json:"user_id"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SpotReviewsDTO holds all reviews of a spot together with the rating aggregate
+type SpotReviewsDTO struct {
+	SpotID        int64       `json:"spot_id"`
+	AverageRating float64     `json:"average_rating"`
+	ReviewCount   int64       `json:"review_count"`
+	Reviews       []ReviewDTO `json:"reviews"`
+}
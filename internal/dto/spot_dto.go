@@ -1,11 +1,64 @@
 package dto
 
 type SpotCardDTO struct {
-	ID        int64   `json:"id"`
-	Name      string  `json:"name"`
-	Category  string  `json:"category"`
-	Address   string  `json:"address"`
-	ImageURL  string  `json:"image_url"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// CategoryName is the category in the language of the request
+	CategoryName string  `json:"category_name"`
+	Address      string  `json:"address"`
+	ImageURL     string  `json:"image_url"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	// AverageRating is 0 when the spot has no reviews yet
+	AverageRating float64 `json:"average_rating"`
+	ReviewCount   int64   `json:"review_count"`
+	// IsFavorite is only set when the request has a session
+	IsFavorite *bool `json:"is_favorite,omitempty"`
+	// DistanceM is only set by distance based searches
+	DistanceM *float64 `json:"distance_m,omitempty"`
+	// OpeningStatus is only set for spots with opening hours
+	OpeningStatus *OpeningStatusDTO `json:"opening_status,omitempty"`
+}
+
+type SpotDetailDTO struct {
+	ID          int64   `json:"id"`
+	Category    string  `json:"category"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	LocationID  int64   `json:"location_id"`
+	Address     string  `json:"address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+// SpotMapDTO is the content of a map viewport, either single spots or clusters
+type SpotMapDTO struct {
+	Zoom      int              `json:"zoom"`
+	Clustered bool             `json:"clustered"`
+	Spots     []SpotCardDTO    `json:"spots"`
+	Clusters  []SpotClusterDTO `json:"clusters,omitempty"`
+}
+
+type SpotClusterDTO struct {
+	Latitude   float64          `json:"latitude"`
+	Longitude  float64          `json:"longitude"`
+	Count      int64            `json:"count"`
+	Categories map[string]int64 `json:"categories"`
+	// SpotID is set when the cluster holds a single spot, so the map can link to it
+	SpotID *int64 `json:"spot_id,omitempty"`
+}
+
+// SpotSearchResultDTO is a search hit, Snippet is HTML escaped with matches wrapped in <mark>
+type SpotSearchResultDTO struct {
+	SpotCardDTO
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// SpotPageDTO is one page of a spot list, NextCursor is null on the last page
+type SpotPageDTO struct {
+	Items      []SpotCardDTO `json:"items"`
+	NextCursor *string       `json:"next_cursor"`
+	Total      int64         `json:"total"`
 }
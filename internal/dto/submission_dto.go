@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type SubmissionDTO struct {
+	ID              int64   `json:"id"`
+	UserID          int64   `json:"user_id"`
+	Category        string  `json:"category"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Address         string  `json:"address"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	Status          string  `json:"status"`
+	RejectionReason string  `json:"rejection_reason,omitempty"`
+	// SpotID points at the spot created when the submission was approved
+	SpotID    *int64               `json:"spot_id,omitempty"`
+	Images    []SubmissionImageDTO `json:"images,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type SubmissionImageDTO struct {
+	ID           int64  `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
@@ -0,0 +1,12 @@
+// Package export writes spots into map file formats. The writers stream: every spot is
+// written as soon as it arrives, so exports of any size use the same amount of memory.
+package export
+
+import "PilaiteProject/internal/dto"
+
+// SpotWriter writes one spot at a time, Close finishes the document. Close must be
+// called even when no spot was written, an empty export is still a valid file.
+type SpotWriter interface {
+	WriteSpot(spot dto.SpotExportDTO) error
+	Close() error
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"PilaiteProject/internal/dto"
+	"encoding/json"
+	"io"
+)
+
+// GeoJSONContentType is the media type registered for GeoJSON (RFC 7946)
+const GeoJSONContentType = "application/geo+json"
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	ID         int64             `json:"id"`
+	Geometry   geoJSONPoint      `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type string `json:"type"`
+	// Coordinates are longitude first, as RFC 7946 requires
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type geoJSONProperties struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Category     string `json:"category"`
+	CategoryName string `json:"category_name"`
+	Address      string `json:"address"`
+	ImageURL     string `json:"image_url"`
+}
+
+// GeoJSONWriter writes a FeatureCollection with one Point feature per spot
+type GeoJSONWriter struct {
+	w       io.Writer
+	written int
+}
+
+func NewGeoJSONWriter(w io.Writer) *GeoJSONWriter {
+	return &GeoJSONWriter{w: w}
+}
+
+func (g *GeoJSONWriter) WriteSpot(spot dto.SpotExportDTO) error {
+	feature, err := json.Marshal(geoJSONFeature{
+		Type: "Feature",
+		ID:   spot.ID,
+		Geometry: geoJSONPoint{
+			Type:        "Point",
+			Coordinates: [2]float64{spot.Longitude, spot.Latitude},
+		},
+		Properties: geoJSONProperties{
+			ID:           spot.ID,
+			Name:         spot.Name,
+			Category:     spot.Category,
+			CategoryName: spot.CategoryName,
+			Address:      spot.Address,
+			ImageURL:     spot.ImageURL,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if g.written == 0 {
+		separator = `{"type":"FeatureCollection","features":[`
+	}
+	if _, err := io.WriteString(g.w, separator); err != nil {
+		return err
+	}
+	if _, err := g.w.Write(feature); err != nil {
+		return err
+	}
+	g.written++
+	return nil
+}
+
+func (g *GeoJSONWriter) Close() error {
+	end := "]}\n"
+	if g.written == 0 {
+		end = `{"type":"FeatureCollection","features":[]}` + "\n"
+	}
+	_, err := io.WriteString(g.w, end)
+	return err
+}
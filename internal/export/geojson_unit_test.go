@@ -0,0 +1,68 @@
+package export
+
+import (
+	"PilaiteProject/internal/dto"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoJSONWriter_FeatureCollection(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewGeoJSONWriter(&buf)
+
+	spots := []dto.SpotExportDTO{
+		{ID: 1, Name: "Pilaitės parkas", Category: "Gamta", CategoryName: "Gamta", Address: "Pilaitės pr. 1", Latitude: 54.707, Longitude: 25.18, ImageURL: "http://localhost/uploads/1.jpg"},
+		{ID: 2, Name: "Treniruokliai", Category: "Lauko_treniruokliai", Latitude: 54.71, Longitude: 25.19},
+	}
+	for _, spot := range spots {
+		if err := writer.WriteSpot(spot); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry struct {
+				Type        string    `json:"type"`
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("unexpected collection: %s", buf.String())
+	}
+	first := collection.Features[0]
+	if first.Geometry.Type != "Point" || first.Geometry.Coordinates[0] != 25.18 || first.Geometry.Coordinates[1] != 54.707 {
+		t.Fatalf("expected [lng, lat] point, got %+v", first.Geometry)
+	}
+	if first.Properties["name"] != "Pilaitės parkas" || first.Properties["image_url"] != "http://localhost/uploads/1.jpg" {
+		t.Fatalf("unexpected properties: %v", first.Properties)
+	}
+}
+
+func TestGeoJSONWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewGeoJSONWriter(&buf)
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var collection map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if features, ok := collection["features"].([]any); !ok || len(features) != 0 {
+		t.Fatalf("expected an empty features array, got %s", buf.String())
+	}
+}
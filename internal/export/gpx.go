@@ -0,0 +1,73 @@
+package export
+
+import (
+	"PilaiteProject/internal/dto"
+	"encoding/xml"
+	"io"
+)
+
+// GPXContentType is the media type GPS devices and hiking apps expect for GPX 1.1
+const GPXContentType = "application/gpx+xml"
+
+// creator names the application in GPX files and KML documents
+const creator = "PilaiteProject"
+
+type gpxWaypoint struct {
+	XMLName     xml.Name `xml:"wpt"`
+	Lat         float64  `xml:"lat,attr"`
+	Lon         float64  `xml:"lon,attr"`
+	Name        string   `xml:"name"`
+	Description string   `xml:"desc,omitempty"`
+	Link        *gpxLink `xml:"link"`
+	Type        string   `xml:"type,omitempty"`
+}
+
+type gpxLink struct {
+	Href string `xml:"href,attr"`
+}
+
+// GPXWriter writes one waypoint per spot. The category name is the waypoint type,
+// which most devices show as the symbol group.
+type GPXWriter struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+}
+
+func NewGPXWriter(w io.Writer) *GPXWriter {
+	return &GPXWriter{w: w, enc: xml.NewEncoder(w)}
+}
+
+func (g *GPXWriter) WriteSpot(spot dto.SpotExportDTO) error {
+	if err := g.start(); err != nil {
+		return err
+	}
+	waypoint := gpxWaypoint{
+		Lat:         spot.Latitude,
+		Lon:         spot.Longitude,
+		Name:        spot.Name,
+		Description: spot.Description,
+		Type:        spot.CategoryName,
+	}
+	if spot.ImageURL != "" {
+		waypoint.Link = &gpxLink{Href: spot.ImageURL}
+	}
+	return g.enc.Encode(waypoint)
+}
+
+func (g *GPXWriter) Close() error {
+	if err := g.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(g.w, "</gpx>\n")
+	return err
+}
+
+func (g *GPXWriter) start() error {
+	if g.started {
+		return nil
+	}
+	g.started = true
+	_, err := io.WriteString(g.w, xml.Header+`<gpx version="1.1" creator="`+creator+`" xmlns="http://www.topografix.com/GPX/1/1">`)
+	return err
+}
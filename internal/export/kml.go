@@ -0,0 +1,116 @@
+package export
+
+import (
+	"PilaiteProject/internal/dto"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// KMLContentType is the media type Google Earth registers for KML
+const KMLContentType = "application/vnd.google-earth.kml+xml"
+
+type kmlPlacemark struct {
+	XMLName     xml.Name  `xml:"Placemark"`
+	ID          string    `xml:"id,attr"`
+	Name        string    `xml:"name"`
+	Address     string    `xml:"address,omitempty"`
+	Description string    `xml:"description,omitempty"`
+	Data        []kmlData `xml:"ExtendedData>Data"`
+	Coordinates string    `xml:"Point>coordinates"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// KMLWriter writes a Document with one Folder per category. Spots must arrive grouped
+// by category, as ExportSpots returns them, a new Folder starts whenever it changes.
+type KMLWriter struct {
+	w        io.Writer
+	enc      *xml.Encoder
+	started  bool
+	category string
+	inFolder bool
+}
+
+func NewKMLWriter(w io.Writer) *KMLWriter {
+	return &KMLWriter{w: w, enc: xml.NewEncoder(w)}
+}
+
+func (k *KMLWriter) WriteSpot(spot dto.SpotExportDTO) error {
+	if err := k.start(); err != nil {
+		return err
+	}
+	if !k.inFolder || spot.Category != k.category {
+		if err := k.endFolder(); err != nil {
+			return err
+		}
+		if err := k.startFolder(spot); err != nil {
+			return err
+		}
+	}
+
+	placemark := kmlPlacemark{
+		ID:          "spot-" + strconv.FormatInt(spot.ID, 10),
+		Name:        spot.Name,
+		Address:     spot.Address,
+		Description: spot.Description,
+		Data:        []kmlData{{Name: "category", Value: spot.Category}},
+		// KML puts longitude first, like GeoJSON
+		Coordinates: strconv.FormatFloat(spot.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(spot.Latitude, 'f', -1, 64),
+	}
+	if spot.ImageURL != "" {
+		placemark.Data = append(placemark.Data, kmlData{Name: "image_url", Value: spot.ImageURL})
+	}
+	return k.enc.Encode(placemark)
+}
+
+func (k *KMLWriter) Close() error {
+	if err := k.start(); err != nil {
+		return err
+	}
+	if err := k.endFolder(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(k.w, "</Document></kml>\n")
+	return err
+}
+
+func (k *KMLWriter) start() error {
+	if k.started {
+		return nil
+	}
+	k.started = true
+	_, err := io.WriteString(k.w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>`+creator+`</name>`)
+	return err
+}
+
+func (k *KMLWriter) startFolder(spot dto.SpotExportDTO) error {
+	name := spot.CategoryName
+	if name == "" {
+		name = spot.Category
+	}
+	if _, err := io.WriteString(k.w, "<Folder><name>"); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(k.w, []byte(name)); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(k.w, "</name>"); err != nil {
+		return err
+	}
+	k.category = spot.Category
+	k.inFolder = true
+	return nil
+}
+
+func (k *KMLWriter) endFolder() error {
+	if !k.inFolder {
+		return nil
+	}
+	k.inFolder = false
+	_, err := io.WriteString(k.w, "</Folder>")
+	return err
+}
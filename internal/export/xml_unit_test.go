@@ -0,0 +1,94 @@
+package export
+
+import (
+	"PilaiteProject/internal/dto"
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+var xmlTestSpots = []dto.SpotExportDTO{
+	{ID: 1, Name: "Ąžuolynas & takas", Category: "Gamta", CategoryName: "Gamta", Description: "<b>Miškas</b>", Latitude: 54.707, Longitude: 25.18, ImageURL: "/uploads/1.jpg"},
+	{ID: 2, Name: "Pušynas", Category: "Gamta", CategoryName: "Gamta", Latitude: 54.71, Longitude: 25.17},
+	{ID: 3, Name: "Treniruokliai", Category: "Lauko_treniruokliai", CategoryName: "Lauko treniruokliai", Latitude: 54.72, Longitude: 25.19},
+}
+
+func writeSpots(t *testing.T, writer SpotWriter, spots []dto.SpotExportDTO) {
+	t.Helper()
+	for _, spot := range spots {
+		if err := writer.WriteSpot(spot); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGPXWriter_Waypoints(t *testing.T) {
+	var buf bytes.Buffer
+	writeSpots(t, NewGPXWriter(&buf), xmlTestSpots)
+
+	var gpx struct {
+		Waypoints []struct {
+			Lat  float64 `xml:"lat,attr"`
+			Lon  float64 `xml:"lon,attr"`
+			Name string  `xml:"name"`
+			Desc string  `xml:"desc"`
+			Type string  `xml:"type"`
+		} `xml:"wpt"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &gpx); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, buf.String())
+	}
+	if len(gpx.Waypoints) != 3 {
+		t.Fatalf("expected 3 waypoints, got %s", buf.String())
+	}
+	first := gpx.Waypoints[0]
+	if first.Lat != 54.707 || first.Lon != 25.18 || first.Name != "Ąžuolynas & takas" || first.Desc != "<b>Miškas</b>" || first.Type != "Gamta" {
+		t.Fatalf("unexpected waypoint: %+v", first)
+	}
+}
+
+func TestKMLWriter_FoldersByCategory(t *testing.T) {
+	var buf bytes.Buffer
+	writeSpots(t, NewKMLWriter(&buf), xmlTestSpots)
+
+	var kml struct {
+		Folders []struct {
+			Name       string `xml:"name"`
+			Placemarks []struct {
+				Name        string `xml:"name"`
+				Coordinates string `xml:"Point>coordinates"`
+			} `xml:"Placemark"`
+		} `xml:"Document>Folder"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &kml); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, buf.String())
+	}
+	if len(kml.Folders) != 2 || kml.Folders[0].Name != "Gamta" || kml.Folders[1].Name != "Lauko treniruokliai" {
+		t.Fatalf("expected a folder per category, got %s", buf.String())
+	}
+	if len(kml.Folders[0].Placemarks) != 2 || kml.Folders[0].Placemarks[0].Coordinates != "25.18,54.707" {
+		t.Fatalf("unexpected placemarks: %+v", kml.Folders[0].Placemarks)
+	}
+}
+
+func TestXMLWriters_Empty(t *testing.T) {
+	for name, writer := range map[string]func(*bytes.Buffer) SpotWriter{
+		"gpx": func(buf *bytes.Buffer) SpotWriter { return NewGPXWriter(buf) },
+		"kml": func(buf *bytes.Buffer) SpotWriter { return NewKMLWriter(buf) },
+	} {
+		var buf bytes.Buffer
+		writeSpots(t, writer(&buf), nil)
+
+		var document struct{}
+		if err := xml.Unmarshal(buf.Bytes(), &document); err != nil {
+			t.Fatalf("%s: output is not valid XML: %v\n%s", name, err, buf.String())
+		}
+		if strings.Contains(buf.String(), "Folder") || strings.Contains(buf.String(), "wpt") {
+			t.Fatalf("%s: expected an empty document, got %s", name, buf.String())
+		}
+	}
+}
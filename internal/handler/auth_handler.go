@@ -2,10 +2,12 @@ package handler
 
 import (
 	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/logging"
+	"PilaiteProject/internal/metrics"
 	"PilaiteProject/internal/service"
 	"encoding/json"
 	"net/http"
-	"unicode"
 
 	"github.com/alexedwards/scs/v2"
 	"golang.org/x/crypto/bcrypt"
@@ -14,12 +16,15 @@ import (
 type AuthHandler struct {
 	userService    *service.UserService
 	sessionManager *scs.SessionManager
+	// logins counts login attempts by result
+	logins *metrics.CounterVec
 }
 
-func NewAuthHandler(userService *service.UserService, sessionManager *scs.SessionManager) *AuthHandler {
+func NewAuthHandler(userService *service.UserService, sessionManager *scs.SessionManager, logins *metrics.CounterVec) *AuthHandler {
 	return &AuthHandler{
 		userService:    userService,
 		sessionManager: sessionManager,
+		logins:         logins,
 	}
 }
 
@@ -49,50 +54,40 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
 		return
 	}
 
 	if req.Email == "" || req.Password == "" || req.ConfirmPassword == "" {
-		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		httpError(w, r, "Missing required fields", http.StatusBadRequest)
 		return
 	}
 
 	if req.Password != req.ConfirmPassword {
-		http.Error(w, "Passwords do not match", http.StatusBadRequest)
+		httpError(w, r, "Passwords do not match", http.StatusBadRequest)
 		return
 	}
 
-	if len(req.Password) < 8 {
-		http.Error(w, "Password must be at least 8 characters", http.StatusBadRequest)
-		return
-	}
-
-	if !hasUppercase(req.Password) {
-		http.Error(w, "Password must have atleast one uppercase", http.StatusBadRequest)
-		return
-	}
-
-	if !hasDigit(req.Password) {
-		http.Error(w, "Password must have at least one digit", http.StatusBadRequest)
+	if err := service.ValidatePassword(req.Password); err != nil {
+		http.Error(w, translateError(r, err), http.StatusBadRequest)
 		return
 	}
 
 	existingUser, err := h.userService.GetUserByEmail(r.Context(), req.Email)
 	if err != nil && existingUser != nil {
-		http.Error(w, "User already exists", http.StatusConflict)
+		httpError(w, r, "User already exists", http.StatusConflict)
 		return
 	}
 
 	hashPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		http.Error(w, "Failed to generate password", http.StatusInternalServerError)
+		httpError(w, r, "Failed to generate password", http.StatusInternalServerError)
 		return
 	}
 
 	role, err := h.userService.StringToUserRole("user")
 	if err != nil {
-		http.Error(w, "Failed to parse user role", http.StatusInternalServerError)
+		httpError(w, r, "Failed to parse user role", http.StatusInternalServerError)
 		return
 	}
 
@@ -103,14 +98,14 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		role,
 	)
 	if err != nil {
-		http.Error(w, "Failed to register user", http.StatusInternalServerError)
+		httpError(w, r, "Failed to register user", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(AuthResponse{
-		Message: "Registration successful",
+		Message: i18n.T(r.Context(), "Registration successful"),
 		User: &UserDTO{
 			ID:    user.ID,
 			Email: user.Email,
@@ -122,30 +117,32 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
 		return
 	}
 
 	if req.Email == "" || req.Password == "" {
-		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		httpError(w, r, "Missing required fields", http.StatusBadRequest)
 		return
 	}
 
 	user, err := h.userService.GetUserByEmail(r.Context(), req.Email)
 	if err != nil {
-		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+		h.logins.Inc("failure")
+		httpError(w, r, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+		h.logins.Inc("failure")
+		httpError(w, r, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
 
 	err = h.sessionManager.RenewToken(r.Context())
 	if err != nil {
-		http.Error(w, "Session error", http.StatusInternalServerError)
+		httpError(w, r, "Session error", http.StatusInternalServerError)
 		return
 	}
 
@@ -153,17 +150,14 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	h.sessionManager.Put(r.Context(), "role", string(user.Role))
 	h.sessionManager.Put(r.Context(), "email", user.Email)
 
-	//testUserID := h.sessionManager.GetInt(r.Context(), "userID")
-	//fmt.Printf("LOGIN DEBUG: Immediately after Put, GetInt returns: %d\n", testUserID)
-
-	//fmt.Printf("LOGIN DEBUG: Session created for user %d\n", user.ID)
-	//fmt.Printf("LOGIN DEBUG: USER EMAIL: %s\n", user.Email)
-	//fmt.Printf("LOGIN DEBUG: Session token in context: %v\n", h.sessionManager.Token(r.Context()))
+	h.logins.Inc("success")
+	logging.SetUserID(r.Context(), int(user.ID))
+	logging.FromContext(r.Context()).Debug("session created", "email", user.Email, "role", user.Role)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(AuthResponse{
-		Message: "Login successful",
+		Message: i18n.T(r.Context(), "Login successful"),
 		User: &UserDTO{
 			ID:    user.ID,
 			Email: user.Email,
@@ -175,25 +169,25 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	err := h.sessionManager.Destroy(r.Context())
 	if err != nil {
-		http.Error(w, "Error logging out", http.StatusInternalServerError)
+		httpError(w, r, "Error logging out", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(AuthResponse{Message: "Logout successful"})
+	json.NewEncoder(w).Encode(AuthResponse{Message: i18n.T(r.Context(), "Logout successful")})
 }
 
 func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	userID := h.sessionManager.GetInt(r.Context(), "userID")
 	if userID == 0 {
-		http.Error(w, "Not authenticated", http.StatusUnauthorized)
+		httpError(w, r, "Not authenticated", http.StatusUnauthorized)
 		return
 	}
 
 	user, err := h.userService.GetUserById(r.Context(), int64(userID))
 	if err != nil {
-		http.Error(w, "Failed to get user", http.StatusInternalServerError)
+		httpError(w, r, "Failed to get user", http.StatusInternalServerError)
 		return
 	}
 
@@ -205,21 +199,3 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		Role:  user.Role,
 	})
 }
-
-func hasDigit(s string) bool {
-	for _, char := range s {
-		if unicode.IsDigit(char) {
-			return true
-		}
-	}
-	return false
-}
-
-func hasUppercase(s string) bool {
-	for _, char := range s {
-		if unicode.IsUpper(char) {
-			return true
-		}
-	}
-	return false
-}
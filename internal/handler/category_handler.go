@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"PilaiteProject/internal/service"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CategoryHandler struct {
+	categoryService *service.CategoryService
+}
+
+func NewCategoryHandler(s *service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: s,
+	}
+}
+
+// CategoryRequest is the body of create and update, names are keyed by language code
+type CategoryRequest struct {
+	Slug         string            `json:"slug"`
+	Names        map[string]string `json:"names"`
+	Icon         string            `json:"icon"`
+	RequiresAuth bool              `json:"requires_auth"`
+	HasEquipment bool              `json:"has_equipment"`
+}
+
+// ReorderCategoriesRequest lists every category slug in the new display order
+type ReorderCategoriesRequest struct {
+	Slugs []string `json:"slugs"`
+}
+
+func (req CategoryRequest) toCategoryInput() service.CategoryInput {
+	return service.CategoryInput{
+		Slug:         req.Slug,
+		Names:        req.Names,
+		Icon:         req.Icon,
+		RequiresAuth: req.RequiresAuth,
+		HasEquipment: req.HasEquipment,
+	}
+}
+
+// GetCategories lists every category, including the ones that require a login,
+// so clients know to hide them from anonymous users
+func (h *CategoryHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.categoryService.GetCategories(r.Context())
+	if err != nil {
+		serviceError(w, r, "Failed to get categories", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categories)
+}
+
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req CategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(r.Context(), req.toCategoryInput())
+	if err != nil {
+		serviceError(w, r, "Failed to create category", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(category)
+}
+
+// UpdateCategory replaces the names, icon and requires_auth flag, the slug comes from the URL
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	var req CategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(r.Context(), chi.URLParam(r, "slug"), req.toCategoryInput())
+	if err != nil {
+		serviceError(w, r, "Failed to update category", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(category)
+}
+
+func (h *CategoryHandler) ReorderCategories(w http.ResponseWriter, r *http.Request) {
+	var req ReorderCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	categories, err := h.categoryService.ReorderCategories(r.Context(), req.Slugs)
+	if err != nil {
+		serviceError(w, r, "Failed to reorder categories", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categories)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/logging"
+	"PilaiteProject/internal/service"
+	"errors"
+	"net/http"
+)
+
+// translatedErrors are the service errors whose text is shown to users as is,
+// anything else is an internal error and keeps its English details
+var translatedErrors = []error{
+	service.ErrSpotNotFound, service.ErrImageNotFound,
+	service.ErrReviewNotFound, service.ErrReviewExists,
+	service.ErrEquipmentNotFound,
+	service.ErrCategoryNotFound, service.ErrCategoryExists,
+	service.ErrSubmissionNotFound, service.ErrSubmissionNotPending,
+	service.ErrForbidden,
+}
+
+// serviceErrorStatus maps service errors to the HTTP status the client should see
+func serviceErrorStatus(err error) int {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrSpotNotFound), errors.Is(err, service.ErrImageNotFound),
+		errors.Is(err, service.ErrReviewNotFound), errors.Is(err, service.ErrSubmissionNotFound),
+		errors.Is(err, service.ErrEquipmentNotFound), errors.Is(err, service.ErrCategoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrReviewExists), errors.Is(err, service.ErrSubmissionNotPending),
+		errors.Is(err, service.ErrCategoryExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// httpError is http.Error with the message translated into the language of the request
+func httpError(w http.ResponseWriter, r *http.Request, message string, status int) {
+	http.Error(w, i18n.T(r.Context(), message), status)
+}
+
+// serviceError answers with "<action>: <error>", both translated, and the status of the error
+func serviceError(w http.ResponseWriter, r *http.Request, action string, err error) {
+	status := serviceErrorStatus(err)
+	if status >= http.StatusInternalServerError {
+		logging.FromContext(r.Context()).Error(action, "error", err)
+	}
+	http.Error(w, i18n.T(r.Context(), action)+": "+translateError(r, err), status)
+}
+
+func translateError(r *http.Request, err error) string {
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		return i18n.Tf(r.Context(), validationErr.Format, validationErr.Args...)
+	}
+	for _, known := range translatedErrors {
+		if errors.Is(err, known) {
+			return i18n.T(r.Context(), known.Error())
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/logging"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// healthCheckTimeout bounds every readiness check, a probe that hangs is a failure
+const healthCheckTimeout = 2 * time.Second
+
+const (
+	healthOK          = "ok"
+	healthReady       = "ready"
+	healthUnavailable = "unavailable"
+	healthDraining    = "draining"
+	healthFailed      = "fail"
+)
+
+// HealthCheck is one dependency the server needs before it can take traffic
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks   []HealthCheck
+	draining func() bool
+}
+
+// NewHealthHandler runs checks on every readiness probe, draining reports whether the
+// server is shutting down
+func NewHealthHandler(draining func() bool, checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks, draining: draining}
+}
+
+// Liveness handles GET /healthz, it answers as long as the process serves requests
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, dto.HealthDTO{Status: healthOK})
+}
+
+// Readiness handles GET /readyz, 200 when every check passes and 503 when one fails
+// or the server is draining
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.draining() {
+		writeHealth(w, http.StatusServiceUnavailable, dto.HealthDTO{Status: healthDraining})
+		return
+	}
+
+	start := time.Now()
+	results := make([]dto.HealthCheckDTO, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runHealthCheck(r.Context(), check)
+		}()
+	}
+	wg.Wait()
+
+	response := dto.HealthDTO{
+		Status:     healthReady,
+		DurationMs: milliseconds(time.Since(start)),
+		Checks:     make(map[string]dto.HealthCheckDTO, len(h.checks)),
+	}
+	status := http.StatusOK
+	for i, check := range h.checks {
+		response.Checks[check.Name] = results[i]
+		if results[i].Status != healthOK {
+			response.Status = healthUnavailable
+			status = http.StatusServiceUnavailable
+			logging.FromContext(r.Context()).Warn("readiness check failed", "check", check.Name, "error", results[i].Error)
+		}
+	}
+	writeHealth(w, status, response)
+}
+
+// runHealthCheck gives up after healthCheckTimeout even when the check ignores its context
+func runHealthCheck(ctx context.Context, check HealthCheck) dto.HealthCheckDTO {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errors.New("timed out")
+	}
+
+	result := dto.HealthCheckDTO{Status: healthOK, DurationMs: milliseconds(time.Since(start))}
+	if err != nil {
+		result.Status = healthFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func writeHealth(w http.ResponseWriter, status int, response dto.HealthDTO) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"PilaiteProject/internal/service"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// multipartOverhead leaves room for form boundaries and headers on top of the file itself
+const multipartOverhead = 1 << 20
+
+type ImageHandler struct {
+	imageService *service.ImageService
+}
+
+func NewImageHandler(s *service.ImageService) *ImageHandler {
+	return &ImageHandler{
+		imageService: s,
+	}
+}
+
+// UploadSpotImage expects a multipart form with the photo in the "image" field
+func (h *ImageHandler) UploadSpotImage(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	contentType, data, ok := readUploadedImage(w, r)
+	if !ok {
+		return
+	}
+
+	image, err := h.imageService.UploadSpotImage(r.Context(), spotId, contentType, data)
+	if err != nil {
+		serviceError(w, r, "Failed to upload image", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(image)
+}
+
+func (h *ImageHandler) GetSpotImages(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	images, err := h.imageService.GetSpotImages(r.Context(), spotId)
+	if err != nil {
+		serviceError(w, r, "Failed to get images", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(images)
+}
+
+func (h *ImageHandler) GetImageMetadata(w http.ResponseWriter, r *http.Request) {
+	spotId, imageId, ok := parseImageID(w, r)
+	if !ok {
+		return
+	}
+
+	metadata, err := h.imageService.GetImageMetadata(r.Context(), spotId, imageId)
+	if err != nil {
+		serviceError(w, r, "Failed to get image metadata", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(metadata)
+}
+
+func (h *ImageHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	spotId, imageId, ok := parseImageID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.imageService.DeleteImage(r.Context(), spotId, imageId); err != nil {
+		serviceError(w, r, "Failed to delete image", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readUploadedImage reads the "image" field of a multipart form, bounded by the upload limit
+func readUploadedImage(w http.ResponseWriter, r *http.Request) (string, []byte, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxImageUploadSize+multipartOverhead)
+	if err := r.ParseMultipartForm(service.MaxImageUploadSize + multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpError(w, r, "Image is too large", http.StatusRequestEntityTooLarge)
+			return "", nil, false
+		}
+		httpError(w, r, "Invalid multipart form", http.StatusBadRequest)
+		return "", nil, false
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		httpError(w, r, "Image file is required", http.StatusBadRequest)
+		return "", nil, false
+	}
+	defer file.Close()
+
+	if header.Size > service.MaxImageUploadSize {
+		httpError(w, r, "Image is too large", http.StatusRequestEntityTooLarge)
+		return "", nil, false
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		httpError(w, r, "Failed to read image", http.StatusBadRequest)
+		return "", nil, false
+	}
+
+	return header.Header.Get("Content-Type"), data, true
+}
+
+// parseImageID reads the {id} and {imageId} URL parameters
+func parseImageID(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+
+	imageId, err := strconv.ParseInt(chi.URLParam(r, "imageId"), 10, 64)
+	if err != nil {
+		httpError(w, r, "Invalid image ID format", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return spotId, imageId, true
+}
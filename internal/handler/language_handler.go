@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"PilaiteProject/internal/i18n"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+// LanguageSessionKey must match the key the language middleware reads
+const LanguageSessionKey = "language"
+
+type LanguageHandler struct {
+	bundle         *i18n.Bundle
+	sessionManager *scs.SessionManager
+}
+
+func NewLanguageHandler(bundle *i18n.Bundle, sessionManager *scs.SessionManager) *LanguageHandler {
+	return &LanguageHandler{
+		bundle:         bundle,
+		sessionManager: sessionManager,
+	}
+}
+
+type LanguageRequest struct {
+	Language string `json:"language"`
+}
+
+type LanguageResponse struct {
+	Language  string   `json:"language"`
+	Supported []string `json:"supported"`
+}
+
+// GetLanguage returns the language responses are currently translated into
+func (h *LanguageHandler) GetLanguage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(LanguageResponse{
+		Language:  i18n.Language(r.Context()),
+		Supported: h.bundle.Languages(),
+	})
+}
+
+// SetLanguage saves the user's language in the session, it wins over Accept-Language
+// until the user logs out. An empty language goes back to Accept-Language.
+func (h *LanguageHandler) SetLanguage(w http.ResponseWriter, r *http.Request) {
+	var req LanguageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	language := strings.ToLower(req.Language)
+	if language == "" {
+		h.sessionManager.Remove(r.Context(), LanguageSessionKey)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !h.bundle.Supports(language) {
+		http.Error(w, i18n.Tf(r.Context(), "Unsupported language, use one of: %s", strings.Join(h.bundle.Languages(), ", ")), http.StatusBadRequest)
+		return
+	}
+
+	h.sessionManager.Put(r.Context(), LanguageSessionKey, language)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(LanguageResponse{
+		Language:  language,
+		Supported: h.bundle.Languages(),
+	})
+}
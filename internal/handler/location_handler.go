@@ -29,13 +29,13 @@ func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request)
 	var req CreateLocationRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
 		return
 	}
 
 	location, err := h.locationService.InsertLocation(r.Context(), req.Address, req.Latitude, req.Longitude)
 	if err != nil {
-		http.Error(w, "Failed to create location: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to create location", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -46,18 +46,18 @@ func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request)
 func (h *LocationHandler) GetLocationById(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "Location ID is needed", http.StatusBadRequest)
+		httpError(w, r, "Location ID is needed", http.StatusBadRequest)
 		return
 	}
 
 	locationId, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid location ID format", http.StatusBadRequest)
+		httpError(w, r, "Invalid location ID format", http.StatusBadRequest)
 		return
 	}
 	location, err := h.locationService.GetLocationById(r.Context(), locationId)
 	if err != nil {
-		http.Error(w, "Failed to get location: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get location", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -67,7 +67,7 @@ func (h *LocationHandler) GetLocationById(w http.ResponseWriter, r *http.Request
 func (h *LocationHandler) GetAllLocations(w http.ResponseWriter, r *http.Request) {
 	locations, err := h.locationService.GetAllLocations(r.Context())
 	if err != nil {
-		http.Error(w, "Failed to get locations: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get locations", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
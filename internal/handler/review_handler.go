@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"PilaiteProject/internal/auth"
+	"PilaiteProject/internal/service"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReviewHandler struct {
+	reviewService *service.ReviewService
+}
+
+func NewReviewHandler(s *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: s,
+	}
+}
+
+type ReviewRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+// GetSpotReviews lists the reviews of a spot with its average rating.
+// Reviews of secret spots are only returned when the request has a session.
+func (h *ReviewHandler) GetSpotReviews(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.reviewService.GetSpotReviews(r.Context(), spotId, auth.IsAuthenticated(r.Context()))
+	if err != nil {
+		serviceError(w, r, "Failed to get reviews", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reviews)
+}
+
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(r.Context(), spotId, userId, service.ReviewInput{
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		serviceError(w, r, "Failed to create review", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(review)
+}
+
+// UpdateReview replaces rating and comment, only the author may edit a review
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	spotId, reviewId, ok := parseReviewID(w, r)
+	if !ok {
+		return
+	}
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.reviewService.UpdateReview(r.Context(), spotId, reviewId, userId, service.ReviewInput{
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		serviceError(w, r, "Failed to update review", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
+
+// DeleteReview removes a review, allowed for its author and for admins
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	spotId, reviewId, ok := parseReviewID(w, r)
+	if !ok {
+		return
+	}
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(r.Context(), spotId, reviewId, userId, auth.IsAdmin(r.Context())); err != nil {
+		serviceError(w, r, "Failed to delete review", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseReviewID reads the {id} and {reviewId} URL parameters
+func parseReviewID(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+
+	reviewId, err := strconv.ParseInt(chi.URLParam(r, "reviewId"), 10, 64)
+	if err != nil {
+		httpError(w, r, "Invalid review ID format", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return spotId, reviewId, true
+}
+
+// requireUserID returns the user that RequireAuth put into the request context
+func requireUserID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userId, ok := auth.UserID(r.Context())
+	if !ok {
+		httpError(w, r, "Unauthorized", http.StatusUnauthorized)
+		return 0, false
+	}
+	return int64(userId), true
+}
+
+// optionalUserID returns the logged in user, 0 for anonymous requests
+func optionalUserID(r *http.Request) int64 {
+	userId, _ := auth.UserID(r.Context())
+	return int64(userId)
+}
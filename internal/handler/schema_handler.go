@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"PilaiteProject/internal/migrate"
+	"encoding/json"
+	"net/http"
+)
+
+type SchemaHandler struct {
+	migrator *migrate.Migrator
+}
+
+func NewSchemaHandler(migrator *migrate.Migrator) *SchemaHandler {
+	return &SchemaHandler{migrator: migrator}
+}
+
+// GetSchemaStatus handles GET /admin/schema, the applied migration version and whether
+// a failed migration left the database dirty
+func (h *SchemaHandler) GetSchemaStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.migrator.Status(r.Context())
+	if err != nil {
+		serviceError(w, r, "Failed to get schema status", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
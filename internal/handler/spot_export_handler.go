@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"PilaiteProject/internal/auth"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/export"
+	"PilaiteProject/internal/logging"
+	"PilaiteProject/internal/service"
+	"io"
+	"net/http"
+)
+
+// ExportGeoJSON handles /spots.geojson?category=&minLat=&minLng=&maxLat=&maxLng=, a
+// FeatureCollection of every visible spot. Secret spots are included only when the
+// request has a session.
+func (h *SpotHandler) ExportGeoJSON(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseSpotExportQuery(w, r)
+	if !ok {
+		return
+	}
+	h.streamSpots(w, r, query, export.GeoJSONContentType, "", func(w io.Writer) export.SpotWriter {
+		return export.NewGeoJSONWriter(w)
+	})
+}
+
+// ExportCategoryGeoJSON handles /spots/category/{category}.geojson
+func (h *SpotHandler) ExportCategoryGeoJSON(w http.ResponseWriter, r *http.Request) {
+	category, ok := h.parseCategoryParam(w, r)
+	if !ok {
+		return
+	}
+	query, ok := parseSpotExportQuery(w, r)
+	if !ok {
+		return
+	}
+	query.Categories = []string{category}
+	h.streamSpots(w, r, query, export.GeoJSONContentType, "", func(w io.Writer) export.SpotWriter {
+		return export.NewGeoJSONWriter(w)
+	})
+}
+
+// ExportGPX handles /spots/export.gpx, a waypoint per spot for GPS devices.
+// It takes the same filters as ExportGeoJSON.
+func (h *SpotHandler) ExportGPX(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseSpotExportQuery(w, r)
+	if !ok {
+		return
+	}
+	h.streamSpots(w, r, query, export.GPXContentType, "spots.gpx", func(w io.Writer) export.SpotWriter {
+		return export.NewGPXWriter(w)
+	})
+}
+
+// ExportKML handles /spots/export.kml, a placemark per spot in a folder per category.
+// It takes the same filters as ExportGeoJSON.
+func (h *SpotHandler) ExportKML(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseSpotExportQuery(w, r)
+	if !ok {
+		return
+	}
+	h.streamSpots(w, r, query, export.KMLContentType, "spots.kml", func(w io.Writer) export.SpotWriter {
+		return export.NewKMLWriter(w)
+	})
+}
+
+// parseSpotExportQuery reads the category filter, which may be repeated or comma
+// separated, and an optional bounding box that needs all four of its parameters
+func parseSpotExportQuery(w http.ResponseWriter, r *http.Request) (service.SpotExportQuery, bool) {
+	params := r.URL.Query()
+	query := service.SpotExportQuery{
+		Categories:    listParam(params["category"]),
+		IncludeSecret: auth.IsAuthenticated(r.Context()),
+	}
+
+	if params.Has("minLat") || params.Has("minLng") || params.Has("maxLat") || params.Has("maxLng") {
+		bbox, ok := parseBBox(w, r)
+		if !ok {
+			return query, false
+		}
+		query.BBox = &bbox
+	}
+	return query, true
+}
+
+// streamSpots writes the spots to the response as they are read, a filename makes it a
+// download. Errors before the first byte are answered as usual, after that the status
+// is already sent and they are only logged.
+func (h *SpotHandler) streamSpots(w http.ResponseWriter, r *http.Request, query service.SpotExportQuery, contentType, filename string, newWriter func(io.Writer) export.SpotWriter) {
+	out := &countingWriter{w: w}
+	writer := newWriter(out)
+
+	w.Header().Set("Content-Type", contentType)
+	if filename != "" {
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	}
+	err := h.spotService.ExportSpots(r.Context(), query, func(spot dto.SpotExportDTO) error {
+		return writer.WriteSpot(spot)
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return
+	}
+	if out.written == 0 {
+		w.Header().Del("Content-Disposition")
+		serviceError(w, r, "Failed to export spots", err)
+		return
+	}
+	logging.FromContext(r.Context()).Error("spot export failed", "bytes", out.written, "error", err)
+}
+
+// countingWriter remembers whether anything reached the client
+type countingWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	return n, err
+}
@@ -1,43 +1,120 @@
 package handler
 
 import (
+	"PilaiteProject/internal/auth"
 	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/i18n"
 	"PilaiteProject/internal/service"
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type SpotHandler struct {
-	spotService service.SpotService
+	spotService  service.SpotService
+	imageService *service.ImageService
 }
 
-func NewSpotHandler(s *service.SpotService) *SpotHandler {
+func NewSpotHandler(s *service.SpotService, i *service.ImageService) *SpotHandler {
 	return &SpotHandler{
-		spotService: *s,
+		spotService:  *s,
+		imageService: i,
 	}
 }
 
-type CreateSpotRequest struct {
-	Category    db.SpotCategory `json:"category"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	LocationID  int64           `json:"location_id"`
+type SpotRequest struct {
+	Category    string   `json:"category"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Address     string   `json:"address"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+type PatchSpotRequest struct {
+	Category    *string  `json:"category"`
+	Name        *string  `json:"name"`
+	Description *string  `json:"description"`
+	Address     *string  `json:"address"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+type EquipmentRequest struct {
+	Quantity  int                   `json:"quantity"`
+	Condition db.EquipmentCondition `json:"condition"`
+}
+
+// OpeningHoursRequest uses HH:MM times in Europe/Vilnius, weekday 1 is Monday
+type OpeningHoursRequest struct {
+	Weekly []struct {
+		Weekday int    `json:"weekday"`
+		Opens   string `json:"opens"`
+		Closes  string `json:"closes"`
+	} `json:"weekly"`
+	Exceptions []struct {
+		Date   string `json:"date"`
+		Opens  string `json:"opens"`
+		Closes string `json:"closes"`
+		Note   string `json:"note"`
+	} `json:"exceptions"`
+}
+
+func (req OpeningHoursRequest) toOpeningHoursInput() service.OpeningHoursInput {
+	var in service.OpeningHoursInput
+	for _, shift := range req.Weekly {
+		in.Weekly = append(in.Weekly, service.OpeningShiftInput{
+			Weekday: shift.Weekday,
+			Opens:   shift.Opens,
+			Closes:  shift.Closes,
+		})
+	}
+	for _, exception := range req.Exceptions {
+		in.Exceptions = append(in.Exceptions, service.OpeningHoursExceptionInput{
+			Date:   exception.Date,
+			Opens:  exception.Opens,
+			Closes: exception.Closes,
+			Note:   exception.Note,
+		})
+	}
+	return in
+}
+
+func (req SpotRequest) toSpotInput() (service.SpotInput, bool) {
+	if req.Latitude == nil || req.Longitude == nil {
+		return service.SpotInput{}, false
+	}
+	return service.SpotInput{
+		Category:    req.Category,
+		Name:        req.Name,
+		Description: req.Description,
+		Address:     req.Address,
+		Latitude:    *req.Latitude,
+		Longitude:   *req.Longitude,
+	}, true
 }
 
 func (h *SpotHandler) InsertSpot(w http.ResponseWriter, r *http.Request) {
-	var req CreateSpotRequest
+	var req SpotRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
 		return
 	}
 
-	spot, err := h.spotService.InsertSpot(r.Context(), req.Category, req.Name, req.Description, req.LocationID)
+	input, ok := req.toSpotInput()
+	if !ok {
+		httpError(w, r, "Latitude and longitude are required", http.StatusBadRequest)
+		return
+	}
+
+	spot, err := h.spotService.CreateSpotWithLocation(r.Context(), input)
 	if err != nil {
-		http.Error(w, "Failed to create spot: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to create spot", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -45,63 +122,197 @@ func (h *SpotHandler) InsertSpot(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(spot)
 }
 
+func (h *SpotHandler) UpdateSpot(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SpotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	input, ok := req.toSpotInput()
+	if !ok {
+		httpError(w, r, "Latitude and longitude are required", http.StatusBadRequest)
+		return
+	}
+
+	spot, err := h.spotService.UpdateSpotWithLocation(r.Context(), spotId, input)
+	if err != nil {
+		serviceError(w, r, "Failed to update spot", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(spot)
+}
+
+func (h *SpotHandler) PatchSpot(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	var req PatchSpotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	spot, err := h.spotService.PatchSpotWithLocation(r.Context(), spotId, service.SpotPatch{
+		Category:    req.Category,
+		Name:        req.Name,
+		Description: req.Description,
+		Address:     req.Address,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	})
+	if err != nil {
+		serviceError(w, r, "Failed to update spot", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(spot)
+}
+
+func (h *SpotHandler) DeleteSpot(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	images, err := h.spotService.DeleteSpotWithLocation(r.Context(), spotId)
+	if err != nil {
+		serviceError(w, r, "Failed to delete spot", err)
+		return
+	}
+	h.imageService.DeleteImageFiles(images)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseSpotID reads the {id} URL parameter and writes a 400 response when it is missing or malformed
+func parseSpotID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		httpError(w, r, "Spot ID is needed", http.StatusBadRequest)
+		return 0, false
+	}
+
+	spotId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		httpError(w, r, "Invalid spot ID format", http.StatusBadRequest)
+		return 0, false
+	}
+	return spotId, true
+}
+
 func (h *SpotHandler) GetSpotById(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "Spot ID is needed", http.StatusBadRequest)
+		httpError(w, r, "Spot ID is needed", http.StatusBadRequest)
 		return
 	}
 
 	spotId, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid spot ID format", http.StatusBadRequest)
+		httpError(w, r, "Invalid spot ID format", http.StatusBadRequest)
 		return
 	}
 	spot, err := h.spotService.GetSpotById(r.Context(), spotId)
 	if err != nil {
-		http.Error(w, "Failed to get spot: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get spot", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(spot)
 }
 
+// GetPublicSpotsWithDetails handles /spots/?category=&sort=&limit=&cursor=, secret spots are never listed
 func (h *SpotHandler) GetPublicSpotsWithDetails(w http.ResponseWriter, r *http.Request) {
-	spots, err := h.spotService.GetPublicSpotsWithDetails(r.Context())
+	query, ok := parseSpotListQuery(w, r)
+	if !ok {
+		return
+	}
+	h.listSpots(w, r, query)
+}
+
+// GetSpotsWithDetails handles /spots/all, the same list as the public one but including secret spots
+func (h *SpotHandler) GetSpotsWithDetails(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseSpotListQuery(w, r)
+	if !ok {
+		return
+	}
+	query.IncludeSecret = true
+	h.listSpots(w, r, query)
+}
+
+// GetSpotsNearby handles /spots/nearby?lat=&lng=&radius=, radius is in meters.
+// Secret spots are included only when the request has a session.
+func (h *SpotHandler) GetSpotsNearby(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(query.Get("lat"), 64)
 	if err != nil {
-		http.Error(w, "Failed to get spots: "+err.Error(), http.StatusInternalServerError)
+		httpError(w, r, "Valid lat is required", http.StatusBadRequest)
 		return
 	}
+	lng, err := strconv.ParseFloat(query.Get("lng"), 64)
+	if err != nil {
+		httpError(w, r, "Valid lng is required", http.StatusBadRequest)
+		return
+	}
+
+	radius := service.DefaultNearbyRadiusM
+	if radiusStr := query.Get("radius"); radiusStr != "" {
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil {
+			httpError(w, r, "Invalid radius", http.StatusBadRequest)
+			return
+		}
+	}
+
+	spots, err := h.spotService.GetSpotsNearby(r.Context(), lat, lng, radius, auth.IsAuthenticated(r.Context()), optionalUserID(r))
+	if err != nil {
+		serviceError(w, r, "Failed to get nearby spots", err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(spots)
 }
 
-func (h *SpotHandler) GetSpotsWithDetails(w http.ResponseWriter, r *http.Request) {
-	spots, err := h.spotService.GetSpotsWithDetails(r.Context())
+// SearchSpots handles /spots/search?q=, secret spots are included only when the request has a session
+func (h *SpotHandler) SearchSpots(w http.ResponseWriter, r *http.Request) {
+	spots, err := h.spotService.SearchSpots(r.Context(), r.URL.Query().Get("q"), auth.IsAuthenticated(r.Context()), optionalUserID(r))
 	if err != nil {
-		http.Error(w, "Failed to get spots: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to search spots", err)
 		return
 	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(spots)
 }
 
-func (h *SpotHandler) GetPublicSpotsByCategoryWithDetails(w http.ResponseWriter, r *http.Request) {
-	categoryStr := chi.URLParam(r, "category")
-	if categoryStr == "" {
-		http.Error(w, "Category is required", http.StatusBadRequest)
+// GetSpotsInBBox handles /spots/bbox?minLat=&minLng=&maxLat=&maxLng=&zoom= for the map view.
+// Low zoom levels get clusters with a category breakdown instead of single spots.
+func (h *SpotHandler) GetSpotsInBBox(w http.ResponseWriter, r *http.Request) {
+	bbox, ok := parseBBox(w, r)
+	if !ok {
 		return
 	}
 
-	category, err := h.spotService.StringToSpotCategory(categoryStr)
+	zoom, err := strconv.Atoi(r.URL.Query().Get("zoom"))
 	if err != nil {
-		http.Error(w, "Invalid category: "+err.Error(), http.StatusBadRequest)
+		httpError(w, r, "Valid zoom is required", http.StatusBadRequest)
 		return
 	}
 
-	spots, err := h.spotService.GetPublicSpotsByCategoryWithDetails(r.Context(), category)
+	spots, err := h.spotService.GetSpotsInBBox(r.Context(), bbox, zoom, auth.IsAuthenticated(r.Context()), optionalUserID(r))
 	if err != nil {
-		http.Error(w, "Failed to get spots: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get spots", err)
 		return
 	}
 
@@ -109,22 +320,104 @@ func (h *SpotHandler) GetPublicSpotsByCategoryWithDetails(w http.ResponseWriter,
 	json.NewEncoder(w).Encode(spots)
 }
 
+// parseBBox reads the minLat, minLng, maxLat and maxLng query parameters
+func parseBBox(w http.ResponseWriter, r *http.Request) (service.BBox, bool) {
+	query := r.URL.Query()
+
+	var bbox service.BBox
+	for _, param := range []struct {
+		name  string
+		value *float64
+	}{
+		{"minLat", &bbox.MinLat},
+		{"minLng", &bbox.MinLng},
+		{"maxLat", &bbox.MaxLat},
+		{"maxLng", &bbox.MaxLng},
+	} {
+		value, err := strconv.ParseFloat(query.Get(param.name), 64)
+		if err != nil {
+			http.Error(w, i18n.Tf(r.Context(), "Valid %s is required", param.name), http.StatusBadRequest)
+			return bbox, false
+		}
+		*param.value = value
+	}
+	return bbox, true
+}
+
+func (h *SpotHandler) GetPublicSpotsByCategoryWithDetails(w http.ResponseWriter, r *http.Request) {
+	category, ok := h.parseCategoryParam(w, r)
+	if !ok {
+		return
+	}
+
+	query, ok := parseSpotListQuery(w, r)
+	if !ok {
+		return
+	}
+	query.Categories = []string{category}
+	h.listSpots(w, r, query)
+}
+
 func (h *SpotHandler) GetSpotsByCategoryWithDetails(w http.ResponseWriter, r *http.Request) {
-	categoryStr := chi.URLParam(r, "category")
-	if categoryStr == "" {
-		http.Error(w, "Category is required", http.StatusBadRequest)
+	category, ok := h.parseCategoryParam(w, r)
+	if !ok {
 		return
 	}
 
-	category, err := h.spotService.StringToSpotCategory(categoryStr)
-	if err != nil {
-		http.Error(w, "Invalid category: "+err.Error(), http.StatusBadRequest)
+	query, ok := parseSpotListQuery(w, r)
+	if !ok {
 		return
 	}
+	query.Categories = []string{category}
+	query.IncludeSecret = true
+	h.listSpots(w, r, query)
+}
 
-	spots, err := h.spotService.GetSpotsByCategoryWithDetails(r.Context(), category)
+// AddFavorite handles PUT /spots/{id}/favorite, saving an already saved spot is fine
+func (h *SpotHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.spotService.AddFavorite(r.Context(), userId, spotId); err != nil {
+		serviceError(w, r, "Failed to add favorite", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SpotHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.spotService.RemoveFavorite(r.Context(), userId, spotId); err != nil {
+		serviceError(w, r, "Failed to remove favorite", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFavoriteSpots handles /me/favorites
+func (h *SpotHandler) GetFavoriteSpots(w http.ResponseWriter, r *http.Request) {
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	spots, err := h.spotService.GetFavoriteSpots(r.Context(), userId)
 	if err != nil {
-		http.Error(w, "Failed to get spots by category: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get favorites", err)
 		return
 	}
 
@@ -132,6 +425,192 @@ func (h *SpotHandler) GetSpotsByCategoryWithDetails(w http.ResponseWriter, r *ht
 	json.NewEncoder(w).Encode(spots)
 }
 
+// GetOpeningHours handles GET /spots/{id}/hours, the schedule with its current status
+func (h *SpotHandler) GetOpeningHours(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	hours, err := h.spotService.GetOpeningHours(r.Context(), spotId, auth.IsAuthenticated(r.Context()))
+	if err != nil {
+		serviceError(w, r, "Failed to get opening hours", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hours)
+}
+
+// SetOpeningHours handles PUT /spots/{id}/hours, the body replaces the whole schedule
+func (h *SpotHandler) SetOpeningHours(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	var req OpeningHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	hours, err := h.spotService.SetOpeningHours(r.Context(), spotId, req.toOpeningHoursInput())
+	if err != nil {
+		serviceError(w, r, "Failed to set opening hours", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hours)
+}
+
+// GetSpotEquipment handles GET /spots/{id}/equipment
+func (h *SpotHandler) GetSpotEquipment(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	equipment, err := h.spotService.GetSpotEquipment(r.Context(), spotId, auth.IsAuthenticated(r.Context()))
+	if err != nil {
+		serviceError(w, r, "Failed to get equipment", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(equipment)
+}
+
+// SetSpotEquipment handles PUT /spots/{id}/equipment/{type}, adding or replacing that equipment
+func (h *SpotHandler) SetSpotEquipment(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	var req EquipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	equipment, err := h.spotService.SetSpotEquipment(r.Context(), spotId, service.EquipmentInput{
+		Type:      db.EquipmentType(chi.URLParam(r, "type")),
+		Quantity:  req.Quantity,
+		Condition: req.Condition,
+	})
+	if err != nil {
+		serviceError(w, r, "Failed to set equipment", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(equipment)
+}
+
+// RemoveSpotEquipment handles DELETE /spots/{id}/equipment/{type}
+func (h *SpotHandler) RemoveSpotEquipment(w http.ResponseWriter, r *http.Request) {
+	spotId, ok := parseSpotID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.spotService.RemoveSpotEquipment(r.Context(), spotId, db.EquipmentType(chi.URLParam(r, "type"))); err != nil {
+		serviceError(w, r, "Failed to remove equipment", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SpotHandler) listSpots(w http.ResponseWriter, r *http.Request, query service.SpotListQuery) {
+	page, err := h.spotService.ListSpots(r.Context(), query)
+	if err != nil {
+		serviceError(w, r, "Failed to get spots", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// parseSpotListQuery reads sort, limit, cursor, category, equipment, open_now and open_at
+// from the query string. category and equipment may be repeated or comma separated,
+// open_at is an RFC 3339 time.
+// Favorites are marked for logged in users.
+func parseSpotListQuery(w http.ResponseWriter, r *http.Request) (service.SpotListQuery, bool) {
+	params := r.URL.Query()
+	query := service.SpotListQuery{
+		Sort:   service.SpotSort(params.Get("sort")),
+		Cursor: params.Get("cursor"),
+	}
+	query.UserID = optionalUserID(r)
+
+	if limitStr := params.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			httpError(w, r, "Invalid limit", http.StatusBadRequest)
+			return query, false
+		}
+		query.Limit = limit
+	}
+
+	if params.Get("open_now") != "" && params.Get("open_at") != "" {
+		httpError(w, r, "Use either open_now or open_at", http.StatusBadRequest)
+		return query, false
+	}
+	if openNow := params.Get("open_now"); openNow != "" {
+		value, err := strconv.ParseBool(openNow)
+		if err != nil {
+			httpError(w, r, "Invalid open_now", http.StatusBadRequest)
+			return query, false
+		}
+		if value {
+			now := time.Now()
+			query.OpenAt = &now
+		}
+	}
+	if openAt := params.Get("open_at"); openAt != "" {
+		at, err := time.Parse(time.RFC3339, openAt)
+		if err != nil {
+			httpError(w, r, "Invalid open_at, expected an RFC 3339 time", http.StatusBadRequest)
+			return query, false
+		}
+		query.OpenAt = &at
+	}
+
+	for _, category := range listParam(params["category"]) {
+		query.Categories = append(query.Categories, category)
+	}
+	for _, equipment := range listParam(params["equipment"]) {
+		query.Equipment = append(query.Equipment, db.EquipmentType(equipment))
+	}
+	return query, true
+}
+
+// listParam reads a query parameter that may be repeated or comma separated
+func listParam(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// parseCategoryParam reads the category slug, whether it exists is checked by ListSpots
+func (h *SpotHandler) parseCategoryParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	category := chi.URLParam(r, "category")
+	if category == "" {
+		httpError(w, r, "Category is required", http.StatusBadRequest)
+		return "", false
+	}
+	return category, true
+}
+
 //func (h *SpotHandler) GetSecretSpotsByCategory(w http.ResponseWriter, r *http.Request) {
 //	// This handler is only for secret category and is protected by auth middleware
 //	category := db.SpotCategorySlaptosVietos
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"PilaiteProject/internal/importer"
+	"PilaiteProject/internal/service"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ImportSpots handles POST /spots/import?format=&dry_run=, a multipart form with the file
+// in the "file" field. Without format it is taken from the file extension (.csv, .geojson
+// or .json). A report with row errors is answered with 422 and nothing is imported.
+func (h *SpotHandler) ImportSpots(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			httpError(w, r, "Invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxImportUploadSize+multipartOverhead)
+	if err := r.ParseMultipartForm(service.MaxImportUploadSize + multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpError(w, r, "Import file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		httpError(w, r, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		httpError(w, r, "Import file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	format := importer.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		format = importFormatFromName(header.Filename)
+	}
+
+	report, err := h.spotService.ImportSpots(r.Context(), format, file, dryRun)
+	if err != nil {
+		serviceError(w, r, "Failed to import spots", err)
+		return
+	}
+
+	status := http.StatusOK
+	switch {
+	case len(report.Errors) > 0:
+		status = http.StatusUnprocessableEntity
+	case !dryRun:
+		status = http.StatusCreated
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
+// importFormatFromName guesses the format from a file extension, unknown ones are
+// left for the service to reject
+func importFormatFromName(name string) importer.Format {
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".csv":
+		return importer.FormatCSV
+	case ".geojson", ".json":
+		return importer.FormatGeoJSON
+	default:
+		return importer.Format(strings.TrimPrefix(ext, "."))
+	}
+}
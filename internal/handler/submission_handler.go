@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/service"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SubmissionHandler struct {
+	submissionService *service.SubmissionService
+}
+
+func NewSubmissionHandler(s *service.SubmissionService) *SubmissionHandler {
+	return &SubmissionHandler{
+		submissionService: s,
+	}
+}
+
+type RejectSubmissionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CreateSubmission lets a logged in user propose a new spot, the body is the same as for spots
+func (h *SubmissionHandler) CreateSubmission(w http.ResponseWriter, r *http.Request) {
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SpotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	input, ok := req.toSpotInput()
+	if !ok {
+		httpError(w, r, "Latitude and longitude are required", http.StatusBadRequest)
+		return
+	}
+
+	submission, err := h.submissionService.CreateSubmission(r.Context(), userId, input)
+	if err != nil {
+		serviceError(w, r, "Failed to create submission", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(submission)
+}
+
+// UploadSubmissionImage expects a multipart form with the photo in the "image" field
+func (h *SubmissionHandler) UploadSubmissionImage(w http.ResponseWriter, r *http.Request) {
+	submissionId, ok := parseSubmissionID(w, r)
+	if !ok {
+		return
+	}
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	contentType, data, ok := readUploadedImage(w, r)
+	if !ok {
+		return
+	}
+
+	image, err := h.submissionService.UploadSubmissionImage(r.Context(), submissionId, userId, contentType, data)
+	if err != nil {
+		serviceError(w, r, "Failed to upload image", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(image)
+}
+
+func (h *SubmissionHandler) GetMySubmissions(w http.ResponseWriter, r *http.Request) {
+	userId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	submissions, err := h.submissionService.GetUserSubmissions(r.Context(), userId)
+	if err != nil {
+		serviceError(w, r, "Failed to get submissions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submissions)
+}
+
+// ListSubmissions is the moderation queue, ?status= defaults to pending
+func (h *SubmissionHandler) ListSubmissions(w http.ResponseWriter, r *http.Request) {
+	status := db.SubmissionStatusPending
+	if value := r.URL.Query().Get("status"); value != "" {
+		status = db.SubmissionStatus(value)
+	}
+
+	submissions, err := h.submissionService.GetSubmissionsByStatus(r.Context(), status)
+	if err != nil {
+		serviceError(w, r, "Failed to get submissions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submissions)
+}
+
+func (h *SubmissionHandler) GetSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionId, ok := parseSubmissionID(w, r)
+	if !ok {
+		return
+	}
+
+	submission, err := h.submissionService.GetSubmission(r.Context(), submissionId)
+	if err != nil {
+		serviceError(w, r, "Failed to get submission", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submission)
+}
+
+// UpdateSubmission lets admins correct a pending submission before approving it
+func (h *SubmissionHandler) UpdateSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionId, ok := parseSubmissionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SpotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	input, ok := req.toSpotInput()
+	if !ok {
+		httpError(w, r, "Latitude and longitude are required", http.StatusBadRequest)
+		return
+	}
+
+	submission, err := h.submissionService.UpdateSubmission(r.Context(), submissionId, input)
+	if err != nil {
+		serviceError(w, r, "Failed to update submission", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submission)
+}
+
+// ApproveSubmission turns a pending submission into a spot, the response holds the new spot_id
+func (h *SubmissionHandler) ApproveSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionId, ok := parseSubmissionID(w, r)
+	if !ok {
+		return
+	}
+	adminId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	submission, err := h.submissionService.ApproveSubmission(r.Context(), submissionId, adminId)
+	if err != nil {
+		serviceError(w, r, "Failed to approve submission", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submission)
+}
+
+func (h *SubmissionHandler) RejectSubmission(w http.ResponseWriter, r *http.Request) {
+	submissionId, ok := parseSubmissionID(w, r)
+	if !ok {
+		return
+	}
+	adminId, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req RejectSubmissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	submission, err := h.submissionService.RejectSubmission(r.Context(), submissionId, adminId, req.Reason)
+	if err != nil {
+		serviceError(w, r, "Failed to reject submission", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(submission)
+}
+
+func parseSubmissionID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	submissionId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpError(w, r, "Invalid submission ID format", http.StatusBadRequest)
+		return 0, false
+	}
+	return submissionId, true
+}
@@ -31,13 +31,13 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req CreateUserRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		httpError(w, r, "Invalid JSON format", http.StatusBadRequest)
 		return
 	}
 
 	user, err := h.userService.InsertUser(r.Context(), req.Email, req.Password, req.Role)
 	if err != nil {
-		http.Error(w, "Failed to create user: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to create user", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -49,18 +49,18 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) GetUserById(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "User ID is needed", http.StatusBadRequest)
+		httpError(w, r, "User ID is needed", http.StatusBadRequest)
 		return
 	}
 
 	userId, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		httpError(w, r, "Invalid user ID format", http.StatusBadRequest)
 		return
 	}
 	user, err := h.userService.GetUserById(r.Context(), userId)
 	if err != nil {
-		http.Error(w, "Failed to get user: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get user", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -70,7 +70,7 @@ func (h *UserHandler) GetUserById(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	users, err := h.userService.GetAllUsers(r.Context())
 	if err != nil {
-		http.Error(w, "Failed to get users: "+err.Error(), http.StatusInternalServerError)
+		serviceError(w, r, "Failed to get users", err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
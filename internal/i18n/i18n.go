@@ -0,0 +1,154 @@
+// Package i18n translates API messages. Message ids are the English texts used in
+// the code, so a message missing from a catalog is the English text itself. That is
+// also why en.json only needs the entries whose wording should differ from the code.
+// Only a language without a catalog falls back to the default language.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is used when the client accepts none of the catalog languages
+const DefaultLanguage = "lt"
+
+//go:embed locales/*.json
+var defaultCatalogs embed.FS
+
+// Bundle holds one message catalog per language, keyed by the English message
+type Bundle struct {
+	catalogs map[string]map[string]string
+}
+
+// NewBundle loads the catalogs shipped with the binary
+func NewBundle() (*Bundle, error) {
+	b := &Bundle{catalogs: map[string]map[string]string{}}
+	if err := b.Load(defaultCatalogs, "locales"); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Load reads every <language>.json file in dir. Messages in the files replace the
+// ones already loaded, so a directory given at startup can override single entries.
+func (b *Bundle) Load(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list message catalogs: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read message catalog %s: %w", file, err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("invalid message catalog %s: %w", file, err)
+		}
+
+		language := strings.ToLower(strings.TrimSuffix(path.Base(file), ".json"))
+		catalog, ok := b.catalogs[language]
+		if !ok {
+			catalog = map[string]string{}
+			b.catalogs[language] = catalog
+		}
+		for id, message := range messages {
+			catalog[id] = message
+		}
+	}
+	return nil
+}
+
+// Languages lists the languages that have a catalog
+func (b *Bundle) Languages() []string {
+	languages := make([]string, 0, len(b.catalogs))
+	for language := range b.catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Supports reports whether there is a catalog for the language
+func (b *Bundle) Supports(language string) bool {
+	_, ok := b.catalogs[language]
+	return ok
+}
+
+// Message returns the translation of the message id in the given language. A
+// language without a catalog gets the default language.
+func (b *Bundle) Message(language, id string) string {
+	catalog, ok := b.catalogs[language]
+	if !ok {
+		catalog = b.catalogs[DefaultLanguage]
+	}
+	if message, ok := catalog[id]; ok {
+		return message
+	}
+	return id
+}
+
+// Negotiate picks the best catalog language for an Accept-Language header,
+// e.g. "en-GB,en;q=0.9,lt;q=0.8", falling back to DefaultLanguage
+func (b *Bundle) Negotiate(acceptLanguage string) string {
+	best, bestQuality := DefaultLanguage, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if _, err := fmt.Sscanf(value, "%g", &quality); err != nil {
+				continue
+			}
+		}
+
+		// Only the primary subtag matters, en-GB and en-US both get the en catalog
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if quality > bestQuality && b.Supports(language) {
+			best, bestQuality = language, quality
+		}
+	}
+	return best
+}
+
+type contextKey struct{}
+
+type localizer struct {
+	bundle   *Bundle
+	language string
+}
+
+// WithLanguage returns a context carrying the bundle and the negotiated language,
+// so T works wherever the request context goes
+func WithLanguage(ctx context.Context, b *Bundle, language string) context.Context {
+	return context.WithValue(ctx, contextKey{}, localizer{bundle: b, language: language})
+}
+
+// Language returns the language of the request, DefaultLanguage when none was negotiated
+func Language(ctx context.Context) string {
+	if l, ok := ctx.Value(contextKey{}).(localizer); ok {
+		return l.language
+	}
+	return DefaultLanguage
+}
+
+// T translates the message into the language of the request. Without a bundle in the
+// context, e.g. in unit tests, the English message is returned.
+func T(ctx context.Context, id string) string {
+	l, ok := ctx.Value(contextKey{}).(localizer)
+	if !ok {
+		return id
+	}
+	return l.bundle.Message(l.language, id)
+}
+
+// Tf translates the format and then fills in the arguments, which are not translated
+func Tf(ctx context.Context, format string, args ...any) string {
+	return fmt.Sprintf(T(ctx, format), args...)
+}
@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNegotiate(t *testing.T) {
+	b, err := NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]string{
+		"":                           DefaultLanguage,
+		"en":                         "en",
+		"en-GB,en;q=0.9":             "en",
+		"de-DE,de;q=0.9,en;q=0.5":    "en",
+		"lt;q=0.4,en;q=0.8":          "en",
+		"EN-us":                      "en",
+		"fr":                         DefaultLanguage,
+		"en;q=abc,lt;q=0.1":          "lt",
+		"lt-LT,lt;q=0.9,en-US;q=0.8": "lt",
+	}
+	for header, want := range cases {
+		if got := b.Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestMessage_FallsBackToIDThenDefaultLanguage(t *testing.T) {
+	b := &Bundle{catalogs: map[string]map[string]string{}}
+	err := b.Load(fstest.MapFS{
+		"lt.json": {Data: []byte(`{"spot not found": "vieta nerasta", "forbidden": "draudžiama"}`)},
+		"en.json": {Data: []byte(`{"forbidden": "not allowed"}`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := b.Message("en", "forbidden"); got != "not allowed" {
+		t.Fatalf("expected the en translation, got %q", got)
+	}
+	// The ids are English, a supported language never gets another language's text
+	if got := b.Message("en", "spot not found"); got != "spot not found" {
+		t.Fatalf("expected the message id, got %q", got)
+	}
+	if got := b.Message("de", "spot not found"); got != "vieta nerasta" {
+		t.Fatalf("expected the default language for an unsupported one, got %q", got)
+	}
+	if got := b.Message("lt", "review not found"); got != "review not found" {
+		t.Fatalf("expected the message id, got %q", got)
+	}
+}
+
+func TestLoad_OverridesBuiltInMessages(t *testing.T) {
+	b, err := NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = b.Load(fstest.MapFS{
+		"locales/lt.json": {Data: []byte(`{"spot not found": "tokios vietos nėra"}`)},
+		"locales/pl.json": {Data: []byte(`{"spot not found": "nie znaleziono miejsca"}`)},
+	}, "locales")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := b.Message("lt", "spot not found"); got != "tokios vietos nėra" {
+		t.Fatalf("expected the override, got %q", got)
+	}
+	if got := b.Message("lt", "forbidden"); got != "draudžiama" {
+		t.Fatalf("built in messages must stay, got %q", got)
+	}
+	if !slices.Contains(b.Languages(), "pl") {
+		t.Fatalf("expected pl to be supported, got %v", b.Languages())
+	}
+}
+
+func TestLoad_InvalidCatalog(t *testing.T) {
+	b := &Bundle{catalogs: map[string]map[string]string{}}
+	err := b.Load(fstest.MapFS{"lt.json": {Data: []byte(`{"a": 1}`)}}, ".")
+	if err == nil {
+		t.Fatal("expected error for a catalog that is not a string map")
+	}
+}
+
+// Translations are passed to fmt.Sprintf with the arguments of the English message,
+// so they must use the same verbs in the same order
+func TestCatalogs_KeepFormatVerbs(t *testing.T) {
+	b, err := NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	verb := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	for language, catalog := range b.catalogs {
+		for id, message := range catalog {
+			if !slices.Equal(verb.FindAllString(id, -1), verb.FindAllString(message, -1)) {
+				t.Errorf("%s: %q does not use the verbs of %q", language, message, id)
+			}
+		}
+	}
+}
+
+func TestT_TranslatesWithBundleInContext(t *testing.T) {
+	if got := Tf(context.Background(), "rating must be between %d and %d", 1, 5); got != "rating must be between 1 and 5" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+
+	b, err := NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := WithLanguage(context.Background(), b, "lt")
+	if got := T(ctx, "spot not found"); got != "vieta nerasta" {
+		t.Fatalf("unexpected translation: %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+
+	// maxPixels guards against decompression bombs, a small file can declare a huge canvas
+	maxPixels = 50_000_000
+
+	jpegQuality = 85
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Decode reads a JPEG or PNG image and returns it together with its format name.
+// The EXIF orientation is applied to the pixels, because the re-encoded file carries
+// no metadata that could rotate it later.
+func Decode(data []byte) (image.Image, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image header: %w", err)
+	}
+	if format != FormatJPEG && format != FormatPNG {
+		return nil, "", ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, "", fmt.Errorf("image is too large: %dx%d", config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+	return ApplyOrientation(img, ExtractMetadata(data, format).Orientation()), format, nil
+}
+
+// Fit scales the image down so it fits inside maxWidth x maxHeight, keeping the aspect ratio.
+// Images that already fit are returned unchanged, they are never scaled up.
+func Fit(img image.Image, maxWidth, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	scale := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	newWidth := max(1, int(float64(width)*scale))
+	newHeight := max(1, int(float64(height)*scale))
+
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// Encode writes the image in the given format. Only pixels are written, so any
+// metadata the original file carried is dropped.
+func Encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode jpeg: %w", err)
+		}
+	case FormatPNG:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("failed to encode png: %w", err)
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	return buf.Bytes(), nil
+}
+
+// Extension returns the file extension used for a format name
+func Extension(format string) string {
+	if format == FormatPNG {
+		return ".png"
+	}
+	return ".jpg"
+}
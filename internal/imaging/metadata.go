@@ -0,0 +1,257 @@
+package imaging
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// Metadata holds the raw metadata blocks found in an uploaded file.
+// The blocks are never written back to stored images, they are only kept for auditing.
+type Metadata struct {
+	EXIF []byte `json:"exif,omitempty"`
+	XMP  string `json:"xmp,omitempty"`
+	IPTC []byte `json:"iptc,omitempty"`
+}
+
+func (m Metadata) Empty() bool {
+	return len(m.EXIF) == 0 && m.XMP == "" && len(m.IPTC) == 0
+}
+
+var (
+	exifHeader         = []byte("Exif\x00\x00")
+	xmpHeader          = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	xmpExtensionHeader = []byte("http://ns.adobe.com/xmp/extension/\x00")
+	iptcHeader         = []byte("Photoshop 3.0\x00")
+	pngSignature       = []byte("\x89PNG\r\n\x1a\n")
+)
+
+const (
+	jpegMarkerAPP1  = 0xE1
+	jpegMarkerAPP13 = 0xED
+	jpegMarkerSOS   = 0xDA
+	jpegMarkerEOI   = 0xD9
+
+	exifOrientationTag = 0x0112
+
+	// xmpExtensionPartHeader is the GUID (32 hex digits), full length and offset in
+	// front of every part of an extended XMP packet
+	xmpExtensionPartHeader = 32 + 4 + 4
+
+	// maxMetadataSize stops a crafted file from making us inflate huge text chunks
+	maxMetadataSize = 1 << 20
+)
+
+// ExtractMetadata collects EXIF, XMP and IPTC blocks from a JPEG or PNG file.
+// Malformed blocks are skipped, metadata is never a reason to reject an upload.
+func ExtractMetadata(data []byte, format string) Metadata {
+	var meta Metadata
+	switch format {
+	case FormatJPEG:
+		meta = extractJPEGMetadata(data)
+	case FormatPNG:
+		meta = extractPNGMetadata(data)
+	}
+	// XMP is stored as JSON text and PostgreSQL rejects \u0000 in JSONB
+	meta.XMP = strings.ReplaceAll(meta.XMP, "\x00", "")
+	return meta
+}
+
+// Orientation returns the EXIF orientation (1-8), 1 when it is missing or invalid
+func (m Metadata) Orientation() int {
+	tiff := m.EXIF
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:8]))
+	if ifdOffset < 8 || ifdOffset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifdOffset:]))
+	for i := 0; i < entries; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+func extractJPEGMetadata(data []byte) Metadata {
+	var meta Metadata
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return meta
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return meta
+		}
+		marker := data[pos+1]
+		// Fill bytes and markers without a payload
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			pos += 2
+			continue
+		}
+		if marker == jpegMarkerSOS || marker == jpegMarkerEOI {
+			return meta
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return meta
+		}
+		payload := data[pos+4 : pos+2+length]
+
+		switch marker {
+		case jpegMarkerAPP1:
+			switch {
+			case bytes.HasPrefix(payload, exifHeader) && meta.EXIF == nil:
+				meta.EXIF = bytes.Clone(payload[len(exifHeader):])
+			case bytes.HasPrefix(payload, xmpHeader):
+				meta.XMP += string(payload[len(xmpHeader):])
+			case bytes.HasPrefix(payload, xmpExtensionHeader):
+				// Writers emit the parts in order, so only the binary part header is dropped
+				part := payload[len(xmpExtensionHeader):]
+				if len(part) > xmpExtensionPartHeader {
+					meta.XMP += string(part[xmpExtensionPartHeader:])
+				}
+			}
+		case jpegMarkerAPP13:
+			if bytes.HasPrefix(payload, iptcHeader) {
+				meta.IPTC = append(meta.IPTC, payload[len(iptcHeader):]...)
+			}
+		}
+
+		pos += 2 + length
+	}
+	return meta
+}
+
+func extractPNGMetadata(data []byte) Metadata {
+	var meta Metadata
+	if !bytes.HasPrefix(data, pngSignature) {
+		return meta
+	}
+
+	pos := len(pngSignature)
+	for pos+8 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		chunkType := string(data[pos+4 : pos+8])
+		if length < 0 || pos+12+length > len(data) {
+			return meta
+		}
+		chunk := data[pos+8 : pos+8+length]
+
+		switch chunkType {
+		case "eXIf":
+			meta.EXIF = bytes.Clone(chunk)
+		case "iTXt":
+			if keyword, text, ok := parseITXt(chunk); ok && keyword == "XML:com.adobe.xmp" {
+				meta.XMP += text
+			}
+		case "tEXt", "zTXt":
+			if keyword, text, ok := parseTextChunk(chunkType, chunk); ok {
+				switch keyword {
+				case "XML:com.adobe.xmp":
+					meta.XMP += text
+				case "Raw profile type iptc":
+					meta.IPTC = append(meta.IPTC, text...)
+				case "Raw profile type exif", "Raw profile type APP1":
+					if meta.EXIF == nil {
+						meta.EXIF = []byte(text)
+					}
+				}
+			}
+		case "IEND":
+			return meta
+		}
+
+		pos += 12 + length
+	}
+	return meta
+}
+
+// parseITXt reads an international text chunk: keyword, compression flag and method,
+// language tag, translated keyword and the text itself
+func parseITXt(chunk []byte) (string, string, bool) {
+	keyword, rest, ok := bytes.Cut(chunk, []byte{0})
+	if !ok || len(rest) < 2 {
+		return "", "", false
+	}
+	compressed := rest[0] == 1
+	rest = rest[2:]
+
+	_, rest, ok = bytes.Cut(rest, []byte{0})
+	if !ok {
+		return "", "", false
+	}
+	_, text, ok := bytes.Cut(rest, []byte{0})
+	if !ok {
+		return "", "", false
+	}
+
+	if compressed {
+		inflated, err := inflate(text)
+		if err != nil {
+			return "", "", false
+		}
+		text = inflated
+	}
+	return string(keyword), string(text), true
+}
+
+func parseTextChunk(chunkType string, chunk []byte) (string, string, bool) {
+	keyword, text, ok := bytes.Cut(chunk, []byte{0})
+	if !ok {
+		return "", "", false
+	}
+	if chunkType == "zTXt" {
+		// First byte is the compression method, zlib is the only one defined
+		if len(text) < 1 {
+			return "", "", false
+		}
+		inflated, err := inflate(text[1:])
+		if err != nil {
+			return "", "", false
+		}
+		text = inflated
+	}
+	return string(keyword), string(text), true
+}
+
+func inflate(data []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxMetadataSize))
+}
@@ -0,0 +1,116 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// jpegWithExif builds a 40x20 JPEG with an EXIF block that carries the given orientation
+func jpegWithExif(t *testing.T, orientation byte) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	// Mark the top left corner so the rotation can be checked
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	// Big endian TIFF header, one IFD entry with the orientation tag
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08,
+		0x00, 0x01,
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	payload = append(payload, []byte("GPS 54.7070N 25.1800E")...)
+
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, encoded[:2]...)
+	result = append(result, segment...)
+	return append(result, encoded[2:]...)
+}
+
+func TestExtractMetadata_FindsExif(t *testing.T) {
+	meta := ExtractMetadata(jpegWithExif(t, 6), FormatJPEG)
+
+	if len(meta.EXIF) == 0 {
+		t.Fatal("expected EXIF block to be found")
+	}
+	if meta.Orientation() != 6 {
+		t.Fatalf("expected orientation 6, got %d", meta.Orientation())
+	}
+}
+
+func TestExtractMetadata_ExtendedXMP(t *testing.T) {
+	encoded := jpegWithExif(t, 1)
+	appendAPP1 := func(result []byte, payload string) []byte {
+		result = append(result, 0xFF, 0xE1, byte((len(payload)+2)>>8), byte(len(payload)+2))
+		return append(result, payload...)
+	}
+
+	result := append([]byte{}, encoded[:2]...)
+	result = appendAPP1(result, "http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>")
+	// GUID, full length 14 and offset 0 in front of the extension
+	result = appendAPP1(result, "http://ns.adobe.com/xmp/extension/\x00"+
+		"0123456789ABCDEF0123456789ABCDEF\x00\x00\x00\x0e\x00\x00\x00\x00<rdf:RDF\x00/>")
+	result = append(result, encoded[2:]...)
+
+	meta := ExtractMetadata(result, FormatJPEG)
+	if meta.XMP != "<x:xmpmeta/><rdf:RDF/>" {
+		t.Fatalf("expected the XMP text without part headers or NUL bytes, got %q", meta.XMP)
+	}
+}
+
+func TestDecode_AppliesOrientation(t *testing.T) {
+	img, _, err := Decode(jpegWithExif(t, 6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() != 20 || bounds.Dy() != 40 {
+		t.Fatalf("expected 20x40 after rotation, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	// A clockwise turn moves the top left corner to the top right
+	r, _, _, _ := img.At(18, 2).RGBA()
+	if r < 0x8000 {
+		t.Fatal("expected the marked corner at the top right")
+	}
+	r, _, _, _ = img.At(2, 2).RGBA()
+	if r > 0x8000 {
+		t.Fatal("expected the top left to be empty after rotation")
+	}
+}
+
+func TestEncode_DropsMetadata(t *testing.T) {
+	img, format, err := Decode(jpegWithExif(t, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encoded, err := Encode(img, format)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !ExtractMetadata(encoded, format).Empty() {
+		t.Fatal("expected re-encoded image to have no metadata")
+	}
+	if bytes.Contains(encoded, []byte("GPS")) {
+		t.Fatal("GPS data leaked into the re-encoded image")
+	}
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// csvColumns must all be present in the header, in any order. Other columns are ignored.
+var csvColumns = []string{"category", "name", "description", "address", "latitude", "longitude"}
+
+// ReadCSV reads a comma separated file whose first line names the columns
+func ReadCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet programs like to start UTF-8 files with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, name := range csvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column: %s", name)
+		}
+	}
+
+	var records []Record
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, csvRecord(line, fields, columns))
+	}
+}
+
+func csvRecord(line int, fields []string, columns map[string]int) Record {
+	record := Record{Row: line}
+	if len(fields) < len(columns) {
+		record.Err = ErrColumnCount
+		return record
+	}
+	field := func(name string) string {
+		return strings.TrimSpace(fields[columns[name]])
+	}
+
+	record.Category = field("category")
+	record.Name = field("name")
+	record.Description = field("description")
+	record.Address = field("address")
+
+	var ok bool
+	if record.Latitude, ok = parseCoordinate(field("latitude")); !ok {
+		record.Err = ErrInvalidLatitude
+		return record
+	}
+	if record.Longitude, ok = parseCoordinate(field("longitude")); !ok {
+		record.Err = ErrInvalidLongitude
+	}
+	return record
+}
+
+// parseCoordinate reads a decimal degree, ParseFloat also accepts NaN and Inf which
+// are never coordinates
+func parseCoordinate(value string) (float64, bool) {
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(coordinate) || math.IsInf(coordinate, 0) {
+		return 0, false
+	}
+	return coordinate, true
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Geometry struct {
+		Type string `json:"type"`
+		// Raw because other geometry types nest their coordinates deeper
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		Category    string `json:"category"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Address     string `json:"address"`
+	} `json:"properties"`
+}
+
+// ReadGeoJSON reads a FeatureCollection of Point features, as written by the GeoJSON export
+// plus a description property
+func ReadGeoJSON(r io.Reader) ([]Record, error) {
+	var collection geoJSONCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, errors.New("the file must be a GeoJSON FeatureCollection")
+	}
+
+	records := make([]Record, len(collection.Features))
+	for i, feature := range collection.Features {
+		records[i] = Record{
+			Row:         i + 1,
+			Category:    feature.Properties.Category,
+			Name:        feature.Properties.Name,
+			Description: feature.Properties.Description,
+			Address:     feature.Properties.Address,
+		}
+		// Longitude comes first, an optional third value is the altitude
+		var coordinates []float64
+		if feature.Geometry.Type != "Point" || json.Unmarshal(feature.Geometry.Coordinates, &coordinates) != nil || len(coordinates) < 2 {
+			records[i].Err = ErrNotPoint
+			continue
+		}
+		records[i].Longitude = coordinates[0]
+		records[i].Latitude = coordinates[1]
+	}
+	return records, nil
+}
@@ -0,0 +1,55 @@
+// Package importer reads spots from CSV and GeoJSON files. It only parses, checking
+// the values and writing them is up to the spot service.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format names a supported file format
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatGeoJSON Format = "geojson"
+)
+
+func (f Format) Valid() bool {
+	return f == FormatCSV || f == FormatGeoJSON
+}
+
+// Row errors, their text is a translatable message
+var (
+	ErrInvalidLatitude  = errors.New("latitude is not a number")
+	ErrInvalidLongitude = errors.New("longitude is not a number")
+	ErrColumnCount      = errors.New("row has the wrong number of columns")
+	ErrNotPoint         = errors.New("geometry must be a Point with longitude and latitude")
+)
+
+// Record is one spot of an import file. Row is the CSV line or the 1-based feature
+// number, Err is set when the row could not be read at all.
+type Record struct {
+	Row         int
+	Category    string
+	Name        string
+	Description string
+	Address     string
+	Latitude    float64
+	Longitude   float64
+	Err         error
+}
+
+// Read parses a whole file. An error means the file itself is unusable, problems
+// with single rows are reported in Record.Err instead.
+func Read(format Format, r io.Reader) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(r)
+	case FormatGeoJSON:
+		return ReadGeoJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
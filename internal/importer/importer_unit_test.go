@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV_ColumnsInAnyOrder(t *testing.T) {
+	file := "\ufeffName,Category,latitude,longitude,address,description,notes\n" +
+		"Pilaitės parkas,Gamta,54.707,25.18,Pilaitės pr. 1,\"Parkas, tvenkinys\",x\n" +
+		"Blogas,Gamta,šiaurė,25.18,Adresas,Aprašymas,\n" +
+		"Trumpas,Gamta\n"
+
+	records, err := ReadCSV(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %+v", records)
+	}
+
+	first := records[0]
+	if first.Row != 2 || first.Name != "Pilaitės parkas" || first.Description != "Parkas, tvenkinys" || first.Latitude != 54.707 || first.Err != nil {
+		t.Fatalf("unexpected first record: %+v", first)
+	}
+	if !errors.Is(records[1].Err, ErrInvalidLatitude) || records[1].Row != 3 {
+		t.Fatalf("expected an invalid latitude on line 3, got %+v", records[1])
+	}
+	if !errors.Is(records[2].Err, ErrColumnCount) {
+		t.Fatalf("expected a column count error, got %+v", records[2])
+	}
+}
+
+func TestReadCSV_NotANumber(t *testing.T) {
+	file := "category,name,description,address,latitude,longitude\n" +
+		"Gamta,Parkas,Parkas,Adresas,NaN,25.18\n" +
+		"Gamta,Parkas,Parkas,Adresas,54.707,-Inf\n"
+
+	records, err := ReadCSV(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(records[0].Err, ErrInvalidLatitude) || !errors.Is(records[1].Err, ErrInvalidLongitude) {
+		t.Fatalf("expected NaN and Inf to be rejected, got %+v", records)
+	}
+}
+
+func TestReadCSV_MissingColumn(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("name,category,latitude,longitude,address\n"))
+	if err == nil || !strings.Contains(err.Error(), "description") {
+		t.Fatalf("expected a missing description column, got %v", err)
+	}
+}
+
+func TestReadGeoJSON_Points(t *testing.T) {
+	file := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[25.18,54.707]},
+		 "properties":{"name":"Parkas","category":"Gamta","address":"Pilaitės pr. 1","description":"Tvenkinys"}},
+		{"type":"Feature","geometry":{"type":"LineString","coordinates":[[25.1,54.7],[25.2,54.8]]},
+		 "properties":{"name":"Takas"}}
+	]}`
+
+	records, err := ReadGeoJSON(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %+v", records)
+	}
+	if records[0].Longitude != 25.18 || records[0].Latitude != 54.707 || records[0].Category != "Gamta" {
+		t.Fatalf("unexpected first record: %+v", records[0])
+	}
+	if !errors.Is(records[1].Err, ErrNotPoint) || records[1].Row != 2 {
+		t.Fatalf("expected the line string to be rejected, got %+v", records[1])
+	}
+}
+
+func TestReadGeoJSON_NotACollection(t *testing.T) {
+	if _, err := ReadGeoJSON(strings.NewReader(`{"type":"Feature"}`)); err == nil {
+		t.Fatalf("expected an error for a single feature")
+	}
+}
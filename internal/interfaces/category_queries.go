@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type CategoryQueries interface {
+	GetCategories(ctx context.Context) ([]db.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error)
+	InsertCategory(ctx context.Context, arg db.InsertCategoryParams) (db.Category, error)
+	UpdateCategory(ctx context.Context, arg db.UpdateCategoryParams) (db.Category, error)
+	ReorderCategories(ctx context.Context, slugs []string) error
+}
@@ -0,0 +1,6 @@
+package interfaces
+
+type FileStorage interface {
+	Save(name string, data []byte) (string, error)
+	Delete(url string) error
+}
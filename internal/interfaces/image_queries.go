@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type ImageQueries interface {
+	GetSpotByID(ctx context.Context, id int64) (db.Spot, error)
+	GetImageByID(ctx context.Context, id int64) (db.Image, error)
+	InsertImage(ctx context.Context, arg db.InsertImageParams) (db.Image, error)
+	GetImagesBySpotID(ctx context.Context, spotID int64) ([]db.Image, error)
+	DeleteImage(ctx context.Context, arg db.DeleteImageParams) (db.Image, error)
+}
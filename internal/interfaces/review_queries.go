@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type ReviewQueries interface {
+	GetSpotByID(ctx context.Context, id int64) (db.Spot, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error)
+	GetReviewByID(ctx context.Context, id int64) (db.Review, error)
+	GetReviewsBySpotID(ctx context.Context, spotID int64) ([]db.Review, error)
+	GetSpotRating(ctx context.Context, spotID int64) (db.GetSpotRatingRow, error)
+	InsertReview(ctx context.Context, arg db.InsertReviewParams) (db.Review, error)
+	UpdateReview(ctx context.Context, arg db.UpdateReviewParams) (db.Review, error)
+	DeleteReview(ctx context.Context, id int64) (db.Review, error)
+}
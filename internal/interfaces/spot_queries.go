@@ -8,9 +8,49 @@ import (
 type SpotQueries interface {
 	InsertSpot(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error)
 	GetSpotByID(ctx context.Context, id int64) (db.Spot, error)
+	GetSpotByIDForUpdate(ctx context.Context, id int64) (db.Spot, error)
 	GetAllSpots(ctx context.Context) ([]db.Spot, error)
-	GetPublicSpotsWithDetails(ctx context.Context) ([]db.GetPublicSpotsWithDetailsRow, error)
-	GetSpotsWithDetails(ctx context.Context) ([]db.GetSpotsWithDetailsRow, error)
-	GetPublicSpotsByCategoryWithDetails(ctx context.Context, category db.SpotCategory) ([]db.GetPublicSpotsByCategoryWithDetailsRow, error)
-	GetSpotsByCategoryWithDetails(ctx context.Context, category db.SpotCategory) ([]db.GetSpotsByCategoryWithDetailsRow, error)
+	ListSpotsByID(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error)
+	ListSpotsByName(ctx context.Context, arg db.ListSpotsByNameParams) ([]db.ListSpotsByNameRow, error)
+	ListSpotsByNewest(ctx context.Context, arg db.ListSpotsByNewestParams) ([]db.ListSpotsByNewestRow, error)
+	CountSpots(ctx context.Context, arg db.CountSpotsParams) (int64, error)
+	GetSpotsInBBox(ctx context.Context, arg db.GetSpotsInBBoxParams) ([]db.GetSpotsInBBoxRow, error)
+	GetSpotClustersInBBox(ctx context.Context, arg db.GetSpotClustersInBBoxParams) ([]db.GetSpotClustersInBBoxRow, error)
+	GetSpotsNearby(ctx context.Context, arg db.GetSpotsNearbyParams) ([]db.GetSpotsNearbyRow, error)
+	SearchSpots(ctx context.Context, arg db.SearchSpotsParams) ([]db.SearchSpotsRow, error)
+	UpdateSpot(ctx context.Context, arg db.UpdateSpotParams) (db.Spot, error)
+	DeleteSpot(ctx context.Context, id int64) (db.Spot, error)
+	// ExportSpotsEach streams the rows of ExportSpots into fn instead of returning a slice
+	ExportSpotsEach(ctx context.Context, arg db.ExportSpotsParams, fn func(db.ExportSpotsRow) error) error
+	GetDuplicateSpotID(ctx context.Context, arg db.GetDuplicateSpotIDParams) (int64, error)
+
+	GetCategories(ctx context.Context) ([]db.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error)
+
+	InsertLocation(ctx context.Context, arg db.InsertLocationParams) (db.Location, error)
+	GetLocationByID(ctx context.Context, id int64) (db.Location, error)
+	UpdateLocation(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error)
+	DeleteLocation(ctx context.Context, id int64) error
+
+	DeleteImagesBySpotID(ctx context.Context, spotID int64) ([]db.Image, error)
+
+	AddFavorite(ctx context.Context, arg db.AddFavoriteParams) error
+	RemoveFavorite(ctx context.Context, arg db.RemoveFavoriteParams) error
+	GetFavoriteSpotIDs(ctx context.Context, arg db.GetFavoriteSpotIDsParams) ([]int64, error)
+	GetFavoriteSpots(ctx context.Context, userID int64) ([]db.GetFavoriteSpotsRow, error)
+
+	GetOpeningHoursBySpotIDs(ctx context.Context, spotIds []int64) ([]db.OpeningHour, error)
+	InsertOpeningHours(ctx context.Context, arg db.InsertOpeningHoursParams) error
+	DeleteOpeningHours(ctx context.Context, spotID int64) error
+	GetOpeningHoursExceptionsBySpotIDs(ctx context.Context, arg db.GetOpeningHoursExceptionsBySpotIDsParams) ([]db.OpeningHoursException, error)
+	InsertOpeningHoursException(ctx context.Context, arg db.InsertOpeningHoursExceptionParams) error
+	DeleteOpeningHoursExceptions(ctx context.Context, spotID int64) error
+
+	GetSpotEquipment(ctx context.Context, spotID int64) ([]db.SpotEquipment, error)
+	UpsertSpotEquipment(ctx context.Context, arg db.UpsertSpotEquipmentParams) (db.SpotEquipment, error)
+	DeleteSpotEquipment(ctx context.Context, arg db.DeleteSpotEquipmentParams) (db.SpotEquipment, error)
+	DeleteSpotEquipmentBySpotID(ctx context.Context, spotID int64) error
+
+	// ExecTx runs fn with queries bound to a single transaction
+	ExecTx(ctx context.Context, fn func(SpotQueries) error) error
 }
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type SubmissionQueries interface {
+	InsertSubmission(ctx context.Context, arg db.InsertSubmissionParams) (db.SpotSubmission, error)
+	GetSubmissionByID(ctx context.Context, id int64) (db.SpotSubmission, error)
+	GetSubmissionByIDForUpdate(ctx context.Context, id int64) (db.SpotSubmission, error)
+	GetSubmissionsByStatus(ctx context.Context, status db.SubmissionStatus) ([]db.SpotSubmission, error)
+	GetSubmissionsByUserID(ctx context.Context, userID int64) ([]db.SpotSubmission, error)
+	UpdateSubmission(ctx context.Context, arg db.UpdateSubmissionParams) (db.SpotSubmission, error)
+	ApproveSubmission(ctx context.Context, arg db.ApproveSubmissionParams) (db.SpotSubmission, error)
+	RejectSubmission(ctx context.Context, arg db.RejectSubmissionParams) (db.SpotSubmission, error)
+
+	InsertSubmissionImage(ctx context.Context, arg db.InsertSubmissionImageParams) (db.SubmissionImage, error)
+	GetSubmissionImages(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error)
+	DeleteSubmissionImages(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error)
+	MoveSubmissionImagesToSpot(ctx context.Context, arg db.MoveSubmissionImagesToSpotParams) error
+
+	GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error)
+	InsertLocation(ctx context.Context, arg db.InsertLocationParams) (db.Location, error)
+	InsertSpot(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error)
+
+	// ExecSubmissionTx runs fn with queries bound to a single transaction
+	ExecSubmissionTx(ctx context.Context, fn func(SubmissionQueries) error) error
+}
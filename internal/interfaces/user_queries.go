@@ -10,4 +10,5 @@ type UserQueries interface {
 	GetUserByEmail(ctx context.Context, email string) (db.User, error)
 	GetAllUsers(ctx context.Context) ([]db.User, error)
 	InsertUser(ctx context.Context, arg db.InsertUserParams) (db.User, error)
+	PromoteUserToAdmin(ctx context.Context, email string) (db.User, error)
 }
@@ -0,0 +1,74 @@
+// Package logging sets up the JSON slog logger and the request scoped loggers built
+// by the chi RequestLogger middleware.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets and personal data in log records
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are redacted whatever their value, matched as substrings of the
+// lower cased attribute key
+var sensitiveKeys = []string{"password", "token", "secret", "cookie", "authorization", "email"}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// New returns a JSON logger writing records at level and above to w
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}))
+}
+
+// ParseLevel accepts debug, info, warn and error, optionally with an offset like info+2
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(s))
+	return level, err
+}
+
+// redact hides sensitive attributes and email addresses inside messages and errors
+func redact(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return slog.String(a.Key, Redacted)
+		}
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if s := a.Value.String(); emailPattern.MatchString(s) {
+			return slog.String(a.Key, emailPattern.ReplaceAllString(s, Redacted))
+		}
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, emailPattern.ReplaceAllString(err.Error(), Redacted))
+		}
+	}
+	return a
+}
+
+// FromContext returns the logger of the request, or the default logger outside of one
+func FromContext(ctx context.Context) *slog.Logger {
+	if entry := entryFromContext(ctx); entry != nil {
+		return entry.logger
+	}
+	return slog.Default()
+}
+
+// SetUserID adds the logged in user to the logger of the request, including the
+// access log line written when it finishes
+func SetUserID(ctx context.Context, userID int) {
+	if entry := entryFromContext(ctx); entry != nil && entry.userID != userID {
+		entry.userID = userID
+		entry.logger = entry.base.With("user_id", userID)
+	}
+}
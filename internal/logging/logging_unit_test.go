@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestNew_RedactsSensitiveData(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.Info("login for jonas@example.com",
+		"password", "hunter2",
+		"session_token", "abc",
+		"email", "jonas@example.com",
+		"note", "contact ona.k@example.lt please",
+		"error", errors.New("user jonas@example.com not found"),
+		"user_id", 7,
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "abc", "jonas@example.com", "ona.k@example.lt"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%q leaked into %s", secret, out)
+		}
+	}
+
+	record := decodeLines(t, &buf)[0]
+	if record["msg"] != "login for "+Redacted || record["user_id"] != float64(7) {
+		t.Fatalf("unexpected record: %v", record)
+	}
+	if record["note"] != "contact "+Redacted+" please" {
+		t.Fatalf("expected only the address to be redacted, got %v", record["note"])
+	}
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	level, err := ParseLevel("warn")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger := New(&buf, level)
+
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "shown" {
+		t.Fatalf("expected only the warning, got %v", records)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Fatalf("expected an error for an unknown level")
+	}
+}
+
+func TestRequestFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelDebug)
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.RequestLogger(&RequestFormatter{Logger: logger}))
+	router.Use(middleware.Recoverer)
+	router.Get("/spots/{id}", func(w http.ResponseWriter, r *http.Request) {
+		SetUserID(r.Context(), 42)
+		FromContext(r.Context()).Debug("loading spot")
+		w.WriteHeader(http.StatusTeapot)
+	})
+	router.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/spots/5", nil))
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected the handler line and the access line, got %v", records)
+	}
+	handlerLine, accessLine := records[0], records[1]
+	if handlerLine["request_id"] == "" || handlerLine["request_id"] != accessLine["request_id"] {
+		t.Fatalf("expected both lines to share the request id: %v", records)
+	}
+	if handlerLine["user_id"] != float64(42) || accessLine["user_id"] != float64(42) {
+		t.Fatalf("expected the user id on both lines: %v", records)
+	}
+	if accessLine["route"] != "/spots/{id}" || accessLine["path"] != "/spots/5" || accessLine["status"] != float64(http.StatusTeapot) {
+		t.Fatalf("unexpected access line: %v", accessLine)
+	}
+	if _, ok := accessLine["latency_ms"]; !ok {
+		t.Fatalf("expected the latency: %v", accessLine)
+	}
+
+	buf.Reset()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+
+	records = decodeLines(t, &buf)
+	if len(records) != 2 || records[0]["panic"] != "boom" {
+		t.Fatalf("expected the panic to be logged, got %v", records)
+	}
+	if records[1]["status"] != float64(http.StatusInternalServerError) || records[1]["level"] != "ERROR" {
+		t.Fatalf("expected an error access line, got %v", records[1])
+	}
+}
+
+func TestFromContext_OutsideRequest(t *testing.T) {
+	if FromContext(t.Context()) != slog.Default() {
+		t.Fatalf("expected the default logger")
+	}
+}
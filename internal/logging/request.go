@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestFormatter plugs into middleware.RequestLogger, it gives every request a
+// logger carrying the request ID and writes one access log line when it finishes
+type RequestFormatter struct {
+	Logger *slog.Logger
+}
+
+func (f *RequestFormatter) NewLogEntry(r *http.Request) middleware.LogEntry {
+	base := f.Logger.With("request_id", middleware.GetReqID(r.Context()))
+	return &entry{base: base, logger: base, request: r}
+}
+
+// entry is the middleware.LogEntry of one request
+type entry struct {
+	// base has the request ID, logger adds the user once known
+	base    *slog.Logger
+	logger  *slog.Logger
+	request *http.Request
+	userID  int
+}
+
+func (e *entry) Write(status, bytes int, header http.Header, elapsed time.Duration, extra interface{}) {
+	// The route pattern is only complete once chi has finished routing
+	route := ""
+	if rctx := chi.RouteContext(e.request.Context()); rctx != nil {
+		route = rctx.RoutePattern()
+	}
+
+	level := slog.LevelInfo
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	e.logger.LogAttrs(e.request.Context(), level, "request",
+		slog.String("method", e.request.Method),
+		slog.String("path", e.request.URL.Path),
+		slog.String("route", route),
+		slog.Int("status", status),
+		slog.Int("bytes", bytes),
+		slog.Float64("latency_ms", float64(elapsed.Microseconds())/1000),
+		slog.String("remote_addr", e.request.RemoteAddr),
+	)
+}
+
+// Panic is called by middleware.Recoverer before it answers with 500
+func (e *entry) Panic(v interface{}, stack []byte) {
+	e.logger.Error("panic", "panic", fmt.Sprint(v), "stack", string(stack))
+}
+
+func entryFromContext(ctx context.Context) *entry {
+	e, _ := ctx.Value(middleware.LogEntryCtxKey).(*entry)
+	return e
+}
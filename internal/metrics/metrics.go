@@ -0,0 +1,299 @@
+// Package metrics is a small Prometheus registry writing the text exposition format.
+// It covers what the server needs: counter and histogram vectors updated in place and
+// gauges or counters read from a function at scrape time.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the version 0.0.4 text format understood by every Prometheus server
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are request latencies in seconds, the Prometheus client defaults
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+}
+
+// family is one metric name with its help and type lines
+type family interface {
+	header() (name, help, kind string)
+	collect(ctx context.Context) ([]sample, error)
+}
+
+type sample struct {
+	suffix string
+	labels []string // name, value pairs
+	value  float64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, f)
+}
+
+// NewCounterVec registers a counter, labelNames can be empty for a plain counter
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{name: name, help: help, labelNames: labelNames, values: map[string]*counterValue{}}
+	r.register(c)
+	return c
+}
+
+// NewHistogramVec registers a histogram with the given upper bounds, +Inf is implied
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	h := &HistogramVec{name: name, help: help, buckets: buckets, labelNames: labelNames, values: map[string]*histogramValue{}}
+	r.register(h)
+	return h
+}
+
+// NewGaugeFunc registers a gauge whose value is read from fn on every scrape, when fn
+// fails the gauge is left out of that scrape
+func (r *Registry) NewGaugeFunc(name, help string, fn func(ctx context.Context) (float64, error)) {
+	r.register(&funcFamily{name: name, help: help, kind: "gauge", fn: fn})
+}
+
+// NewCounterFunc is NewGaugeFunc for values that only go up, such as totals kept by
+// a library
+func (r *Registry) NewCounterFunc(name, help string, fn func(ctx context.Context) (float64, error)) {
+	r.register(&funcFamily{name: name, help: help, kind: "counter", fn: fn})
+}
+
+// Write writes every family in registration order
+func (r *Registry) Write(ctx context.Context, w io.Writer) error {
+	r.mu.Lock()
+	families := append([]family(nil), r.families...)
+	r.mu.Unlock()
+
+	out := bufio.NewWriter(w)
+	for _, f := range families {
+		name, help, kind := f.header()
+		samples, err := f.collect(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to collect metric", "metric", name, "error", err)
+			continue
+		}
+		out.WriteString("# HELP " + name + " " + escapeHelp(help) + "\n")
+		out.WriteString("# TYPE " + name + " " + kind + "\n")
+		for _, s := range samples {
+			writeSample(out, name, s)
+		}
+	}
+	return out.Flush()
+}
+
+// Handler serves the registry for Prometheus to scrape
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.Write(req.Context(), w)
+	})
+}
+
+type CounterVec struct {
+	name, help string
+	labelNames []string
+	mu         sync.Mutex
+	values     map[string]*counterValue
+}
+
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+// Inc adds one to the counter with the given label values. A nil counter does
+// nothing, so callers don't need to check whether metrics are set up.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if c == nil {
+		return
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		value = &counterValue{labels: pairs(c.labelNames, labelValues)}
+		c.values[key] = value
+	}
+	value.value += v
+}
+
+func (c *CounterVec) header() (string, string, string) {
+	return c.name, c.help, "counter"
+}
+
+func (c *CounterVec) collect(ctx context.Context) ([]sample, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	samples := make([]sample, 0, len(c.values))
+	for _, key := range sortedKeys(c.values) {
+		value := c.values[key]
+		samples = append(samples, sample{labels: value.labels, value: value.value})
+	}
+	return samples, nil
+}
+
+type HistogramVec struct {
+	name, help string
+	buckets    []float64
+	labelNames []string
+	mu         sync.Mutex
+	values     map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labels []string
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// Observe records v for the given label values, a nil histogram does nothing
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	if h == nil {
+		return
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	value, ok := h.values[key]
+	if !ok {
+		value = &histogramValue{labels: pairs(h.labelNames, labelValues), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = value
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		value.counts[i]++
+	}
+	value.sum += v
+	value.count++
+}
+
+func (h *HistogramVec) header() (string, string, string) {
+	return h.name, h.help, "histogram"
+}
+
+func (h *HistogramVec) collect(ctx context.Context) ([]sample, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var samples []sample
+	for _, key := range sortedKeys(h.values) {
+		value := h.values[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += value.counts[i]
+			samples = append(samples, sample{
+				suffix: "_bucket",
+				labels: append(append([]string(nil), value.labels...), "le", formatFloat(upper)),
+				value:  float64(cumulative),
+			})
+		}
+		samples = append(samples,
+			sample{suffix: "_bucket", labels: append(append([]string(nil), value.labels...), "le", "+Inf"), value: float64(value.count)},
+			sample{suffix: "_sum", labels: value.labels, value: value.sum},
+			sample{suffix: "_count", labels: value.labels, value: float64(value.count)},
+		)
+	}
+	return samples, nil
+}
+
+type funcFamily struct {
+	name, help, kind string
+	fn               func(ctx context.Context) (float64, error)
+}
+
+func (f *funcFamily) header() (string, string, string) {
+	return f.name, f.help, f.kind
+}
+
+func (f *funcFamily) collect(ctx context.Context) ([]sample, error) {
+	v, err := f.fn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []sample{{value: v}}, nil
+}
+
+// pairs zips label names and values, missing values are empty
+func pairs(names, values []string) []string {
+	labels := make([]string, 0, 2*len(names))
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		labels = append(labels, name, value)
+	}
+	return labels
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeSample(w *bufio.Writer, name string, s sample) {
+	w.WriteString(name + s.suffix)
+	if len(s.labels) > 0 {
+		w.WriteByte('{')
+		for i := 0; i < len(s.labels); i += 2 {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(s.labels[i] + `="` + escapeLabel(s.labels[i+1]) + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteString(" " + formatFloat(s.value) + "\n")
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
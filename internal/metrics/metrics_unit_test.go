@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T, registry *Registry) string {
+	t.Helper()
+	var out strings.Builder
+	if err := registry.Write(context.Background(), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out.String()
+}
+
+func TestCounterVec(t *testing.T) {
+	registry := NewRegistry()
+	logins := registry.NewCounterVec("logins_total", "Login attempts.", "result")
+
+	logins.Inc("success")
+	logins.Inc("failure")
+	logins.Add(2, "success")
+
+	want := `# HELP logins_total Login attempts.
+# TYPE logins_total counter
+logins_total{result="failure"} 1
+logins_total{result="success"} 3
+`
+	if got := scrape(t, registry); got != want {
+		t.Fatalf("unexpected output:\n%s", got)
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	registry := NewRegistry()
+	latency := registry.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.1, "/a")
+	latency.Observe(0.5, "/a")
+	latency.Observe(3, "/a")
+
+	want := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/a",le="0.1"} 2
+latency_seconds_bucket{route="/a",le="1"} 3
+latency_seconds_bucket{route="/a",le="+Inf"} 4
+latency_seconds_sum{route="/a"} 3.65
+latency_seconds_count{route="/a"} 4
+`
+	if got := scrape(t, registry); got != want {
+		t.Fatalf("unexpected output:\n%s", got)
+	}
+}
+
+func TestFuncs_SkipFailures(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewGaugeFunc("broken", "Fails.", func(context.Context) (float64, error) {
+		return 0, errors.New("database is down")
+	})
+	registry.NewCounterFunc("acquires_total", "Acquires.", func(context.Context) (float64, error) {
+		return 12, nil
+	})
+
+	want := `# HELP acquires_total Acquires.
+# TYPE acquires_total counter
+acquires_total 12
+`
+	if got := scrape(t, registry); got != want {
+		t.Fatalf("unexpected output:\n%s", got)
+	}
+}
+
+func TestEscaping(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounterVec("requests_total", "Line one\nline two.", "route").Inc(`/a"b\c`)
+
+	got := scrape(t, registry)
+	if !strings.Contains(got, `# HELP requests_total Line one\nline two.`) {
+		t.Fatalf("help not escaped:\n%s", got)
+	}
+	if !strings.Contains(got, `requests_total{route="/a\"b\\c"} 1`) {
+		t.Fatalf("label not escaped:\n%s", got)
+	}
+}
+
+func TestNilMetricsDoNothing(t *testing.T) {
+	var counter *CounterVec
+	var histogram *HistogramVec
+	counter.Inc("success")
+	histogram.Observe(1)
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounterVec("up_total", "Up.").Inc()
+
+	rr := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	if rr.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("unexpected content type %q", rr.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rr.Body.String(), "up_total 1\n") {
+		t.Fatalf("unexpected body:\n%s", rr.Body.String())
+	}
+}
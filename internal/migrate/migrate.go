@@ -0,0 +1,313 @@
+// Package migrate applies the SQL files in db/migration. It keeps its state in the same
+// schema_migrations table as the golang-migrate CLI, so databases set up with the
+// Taskfile carry on where they are.
+//
+// Every migration runs in a transaction together with the version update, so a failing
+// file leaves the schema untouched. The dirty flag is still reported and can be cleared
+// with Force, databases migrated by the old CLI may have it set.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// undefinedTable is the SQLSTATE of a query on a table that does not exist
+const undefinedTable = "42P01"
+
+// lockID is the pg_advisory_lock key that keeps instances started at the same
+// time from migrating concurrently
+const lockID int64 = 7_411_204_002
+
+// conn is a pool or a single connection
+type conn interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// ErrDirty means an earlier migration failed halfway, the schema has to be fixed by hand
+var ErrDirty = errors.New("the database is in a dirty state")
+
+// Migration is one numbered pair of up and down files
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is where the database stands, Version 0 means nothing is applied yet
+type Status struct {
+	Version int64 `json:"version"`
+	Dirty   bool  `json:"dirty"`
+	Latest  int64 `json:"latest"`
+	Pending int   `json:"pending"`
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// New reads the migrations from fsys, every version needs both an up and a down file
+func New(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Load reads the NNNNNN_name.up.sql and NNNNNN_name.down.sql files in version order
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Status reports the applied version and how many migrations are still to run. It does
+// not wait for a running migration, the version is the one committed last.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	version, dirty, err := readVersion(ctx, m.pool)
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{Version: version, Dirty: dirty}
+	for _, migration := range m.migrations {
+		status.Latest = migration.Version
+		if migration.Version > version {
+			status.Pending++
+		}
+	}
+	return status, nil
+}
+
+// Up applies up to steps pending migrations, all of them when steps is 0 or less.
+// It returns the migrations that were applied.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(c conn) error {
+		version, err := cleanVersion(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if steps > 0 && len(applied) == steps {
+				break
+			}
+			if err := run(ctx, c, migration.Up, migration.Version, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back up to steps applied migrations, all of them when steps is 0 or less.
+// It returns the migrations that were rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(c conn) error {
+		version, err := cleanVersion(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+			if steps > 0 && len(reverted) == steps {
+				break
+			}
+			previous := int64(0)
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			if err := run(ctx, c, migration.Down, previous, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force records version as applied and clean without running anything. It is the way
+// out of a dirty state once the schema has been fixed by hand.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	known := version == 0
+	for _, migration := range m.migrations {
+		known = known || migration.Version == version
+	}
+	if !known {
+		return fmt.Errorf("there is no migration %d", version)
+	}
+
+	return m.withLock(ctx, func(c conn) error {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		if err := writeVersion(ctx, tx, version, false); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	})
+}
+
+// withLock runs fn on one connection that holds the advisory lock, other instances
+// wait until it is done
+func (m *Migrator) withLock(ctx context.Context, fn func(conn) error) error {
+	c, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer c.Release()
+
+	if _, err := c.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	// Unlocked even when ctx is cancelled, the connection goes back to the pool
+	defer c.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+
+	if err := ensureTable(ctx, c); err != nil {
+		return err
+	}
+	return fn(c)
+}
+
+// run executes one file and records the new version in the same transaction, so a
+// failing migration leaves the schema as it was instead of dirty
+func run(ctx context.Context, c conn, sql string, newVersion int64, migration Migration) error {
+	tx, err := c.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	if err := writeVersion(ctx, tx, newVersion, false); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func cleanVersion(ctx context.Context, c conn) (int64, error) {
+	version, dirty, err := readVersion(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d, fix the schema and run migrate force", ErrDirty, version)
+	}
+	return version, nil
+}
+
+func ensureTable(ctx context.Context, c conn) error {
+	_, err := c.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// readVersion returns version 0 while nothing was migrated, even before the table exists
+func readVersion(ctx context.Context, c conn) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := c.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	var pgErr *pgconn.PgError
+	if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == undefinedTable) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// writeVersion replaces the single row, version 0 leaves the table empty like golang-migrate does
+func writeVersion(ctx context.Context, tx pgx.Tx, version int64, dirty bool) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("failed to write schema version: %w", err)
+	}
+	if version == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty); err != nil {
+		return fmt.Errorf("failed to write schema version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"PilaiteProject/db/migration"
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad_SortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_b.up.sql":      {Data: []byte("ALTER TABLE a ADD b int;")},
+		"000002_add_b.down.sql":    {Data: []byte("ALTER TABLE a DROP b;")},
+		"000001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id int);")},
+		"000001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+		"README.md":                {Data: []byte("not a migration")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Name != "add_b" {
+		t.Fatalf("unexpected migrations: %+v", migrations)
+	}
+	if migrations[0].Down != "DROP TABLE a;" {
+		t.Fatalf("unexpected down file: %q", migrations[0].Down)
+	}
+}
+
+func TestLoad_MissingDownFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_create_a.up.sql": {Data: []byte("CREATE TABLE a (id int);")},
+	}
+
+	_, err := Load(fsys)
+	if err == nil || !strings.Contains(err.Error(), "down") {
+		t.Fatalf("expected a missing down file error, got %v", err)
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(migration.FS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatalf("expected the migrations to be embedded")
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Fatalf("expected migration %d, got %d_%s", i+1, m.Version, m.Name)
+		}
+	}
+}
+
+func TestForce_UnknownVersion(t *testing.T) {
+	// No pool, the version is checked before connecting
+	migrator := &Migrator{migrations: []Migration{{Version: 1, Name: "create_a"}}}
+
+	if err := migrator.Force(context.Background(), 2); err == nil {
+		t.Fatalf("expected an error for a version without migration")
+	}
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type MockCategoryQueries struct {
+	GetCategoriesFunc     func(ctx context.Context) ([]db.Category, error)
+	GetCategoryBySlugFunc func(ctx context.Context, slug string) (db.Category, error)
+	InsertCategoryFunc    func(ctx context.Context, arg db.InsertCategoryParams) (db.Category, error)
+	UpdateCategoryFunc    func(ctx context.Context, arg db.UpdateCategoryParams) (db.Category, error)
+	ReorderCategoriesFunc func(ctx context.Context, slugs []string) error
+}
+
+func (m *MockCategoryQueries) GetCategories(ctx context.Context) ([]db.Category, error) {
+	return m.GetCategoriesFunc(ctx)
+}
+
+func (m *MockCategoryQueries) GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error) {
+	return m.GetCategoryBySlugFunc(ctx, slug)
+}
+
+func (m *MockCategoryQueries) InsertCategory(ctx context.Context, arg db.InsertCategoryParams) (db.Category, error) {
+	return m.InsertCategoryFunc(ctx, arg)
+}
+
+func (m *MockCategoryQueries) UpdateCategory(ctx context.Context, arg db.UpdateCategoryParams) (db.Category, error) {
+	return m.UpdateCategoryFunc(ctx, arg)
+}
+
+func (m *MockCategoryQueries) ReorderCategories(ctx context.Context, slugs []string) error {
+	return m.ReorderCategoriesFunc(ctx, slugs)
+}
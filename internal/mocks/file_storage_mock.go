@@ -0,0 +1,21 @@
+package mocks
+
+// MockFileStorage keeps saved files in memory
+type MockFileStorage struct {
+	Files map[string][]byte
+}
+
+func NewMockFileStorage() *MockFileStorage {
+	return &MockFileStorage{Files: map[string][]byte{}}
+}
+
+func (m *MockFileStorage) Save(name string, data []byte) (string, error) {
+	url := "/uploads/" + name
+	m.Files[url] = data
+	return url, nil
+}
+
+func (m *MockFileStorage) Delete(url string) error {
+	delete(m.Files, url)
+	return nil
+}
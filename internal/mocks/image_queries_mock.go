@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type MockImageQueries struct {
+	GetSpotByIDFunc       func(ctx context.Context, id int64) (db.Spot, error)
+	GetImageByIDFunc      func(ctx context.Context, id int64) (db.Image, error)
+	InsertImageFunc       func(ctx context.Context, arg db.InsertImageParams) (db.Image, error)
+	GetImagesBySpotIDFunc func(ctx context.Context, spotID int64) ([]db.Image, error)
+	DeleteImageFunc       func(ctx context.Context, arg db.DeleteImageParams) (db.Image, error)
+}
+
+func (m *MockImageQueries) GetSpotByID(ctx context.Context, id int64) (db.Spot, error) {
+	return m.GetSpotByIDFunc(ctx, id)
+}
+
+func (m *MockImageQueries) GetImageByID(ctx context.Context, id int64) (db.Image, error) {
+	return m.GetImageByIDFunc(ctx, id)
+}
+
+func (m *MockImageQueries) InsertImage(ctx context.Context, arg db.InsertImageParams) (db.Image, error) {
+	return m.InsertImageFunc(ctx, arg)
+}
+
+func (m *MockImageQueries) GetImagesBySpotID(ctx context.Context, spotID int64) ([]db.Image, error) {
+	return m.GetImagesBySpotIDFunc(ctx, spotID)
+}
+
+func (m *MockImageQueries) DeleteImage(ctx context.Context, arg db.DeleteImageParams) (db.Image, error) {
+	return m.DeleteImageFunc(ctx, arg)
+}
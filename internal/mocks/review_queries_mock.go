@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"PilaiteProject/internal/db"
+	"context"
+)
+
+type MockReviewQueries struct {
+	GetCategoryBySlugFunc  func(ctx context.Context, slug string) (db.Category, error)
+	GetSpotByIDFunc        func(ctx context.Context, id int64) (db.Spot, error)
+	GetReviewByIDFunc      func(ctx context.Context, id int64) (db.Review, error)
+	GetReviewsBySpotIDFunc func(ctx context.Context, spotID int64) ([]db.Review, error)
+	GetSpotRatingFunc      func(ctx context.Context, spotID int64) (db.GetSpotRatingRow, error)
+	InsertReviewFunc       func(ctx context.Context, arg db.InsertReviewParams) (db.Review, error)
+	UpdateReviewFunc       func(ctx context.Context, arg db.UpdateReviewParams) (db.Review, error)
+	DeleteReviewFunc       func(ctx context.Context, id int64) (db.Review, error)
+}
+
+func (m *MockReviewQueries) GetSpotByID(ctx context.Context, id int64) (db.Spot, error) {
+	return m.GetSpotByIDFunc(ctx, id)
+}
+
+func (m *MockReviewQueries) GetReviewByID(ctx context.Context, id int64) (db.Review, error) {
+	return m.GetReviewByIDFunc(ctx, id)
+}
+
+func (m *MockReviewQueries) GetReviewsBySpotID(ctx context.Context, spotID int64) ([]db.Review, error) {
+	return m.GetReviewsBySpotIDFunc(ctx, spotID)
+}
+
+func (m *MockReviewQueries) GetSpotRating(ctx context.Context, spotID int64) (db.GetSpotRatingRow, error) {
+	return m.GetSpotRatingFunc(ctx, spotID)
+}
+
+func (m *MockReviewQueries) InsertReview(ctx context.Context, arg db.InsertReviewParams) (db.Review, error) {
+	return m.InsertReviewFunc(ctx, arg)
+}
+
+func (m *MockReviewQueries) UpdateReview(ctx context.Context, arg db.UpdateReviewParams) (db.Review, error) {
+	return m.UpdateReviewFunc(ctx, arg)
+}
+
+func (m *MockReviewQueries) DeleteReview(ctx context.Context, id int64) (db.Review, error) {
+	return m.DeleteReviewFunc(ctx, id)
+}
+
+func (m *MockReviewQueries) GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error) {
+	return m.GetCategoryBySlugFunc(ctx, slug)
+}
@@ -2,23 +2,60 @@ package mocks
 
 import (
 	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/interfaces"
 	"context"
 )
 
 type MockSpotQueries struct {
-	GetSpotByIDFunc                         func(ctx context.Context, id int64) (db.Spot, error)
-	GetAllSpotsFunc                         func(ctx context.Context) ([]db.Spot, error)
-	InsertSpotFunc                          func(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error)
-	GetPublicSpotsWithDetailsFunc           func(ctx context.Context) ([]db.GetPublicSpotsWithDetailsRow, error)
-	GetSpotsWithDetailsFunc                 func(ctx context.Context) ([]db.GetSpotsWithDetailsRow, error)
-	GetPublicSpotsByCategoryWithDetailsFunc func(ctx context.Context, category db.SpotCategory) ([]db.GetPublicSpotsByCategoryWithDetailsRow, error)
-	GetSpotsByCategoryWithDetailsFunc       func(ctx context.Context, category db.SpotCategory) ([]db.GetSpotsByCategoryWithDetailsRow, error)
+	GetSpotByIDFunc           func(ctx context.Context, id int64) (db.Spot, error)
+	GetSpotByIDForUpdateFunc  func(ctx context.Context, id int64) (db.Spot, error)
+	GetAllSpotsFunc           func(ctx context.Context) ([]db.Spot, error)
+	InsertSpotFunc            func(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error)
+	ListSpotsByIDFunc         func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error)
+	ListSpotsByNameFunc       func(ctx context.Context, arg db.ListSpotsByNameParams) ([]db.ListSpotsByNameRow, error)
+	ListSpotsByNewestFunc     func(ctx context.Context, arg db.ListSpotsByNewestParams) ([]db.ListSpotsByNewestRow, error)
+	CountSpotsFunc            func(ctx context.Context, arg db.CountSpotsParams) (int64, error)
+	GetSpotsInBBoxFunc        func(ctx context.Context, arg db.GetSpotsInBBoxParams) ([]db.GetSpotsInBBoxRow, error)
+	GetSpotClustersInBBoxFunc func(ctx context.Context, arg db.GetSpotClustersInBBoxParams) ([]db.GetSpotClustersInBBoxRow, error)
+	GetSpotsNearbyFunc        func(ctx context.Context, arg db.GetSpotsNearbyParams) ([]db.GetSpotsNearbyRow, error)
+	SearchSpotsFunc           func(ctx context.Context, arg db.SearchSpotsParams) ([]db.SearchSpotsRow, error)
+	UpdateSpotFunc            func(ctx context.Context, arg db.UpdateSpotParams) (db.Spot, error)
+	DeleteSpotFunc            func(ctx context.Context, id int64) (db.Spot, error)
+	ExportSpotsEachFunc       func(ctx context.Context, arg db.ExportSpotsParams, fn func(db.ExportSpotsRow) error) error
+	GetDuplicateSpotIDFunc    func(ctx context.Context, arg db.GetDuplicateSpotIDParams) (int64, error)
+	GetCategoriesFunc         func(ctx context.Context) ([]db.Category, error)
+	GetCategoryBySlugFunc     func(ctx context.Context, slug string) (db.Category, error)
+	InsertLocationFunc        func(ctx context.Context, arg db.InsertLocationParams) (db.Location, error)
+	GetLocationByIDFunc       func(ctx context.Context, id int64) (db.Location, error)
+	UpdateLocationFunc        func(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error)
+	DeleteLocationFunc        func(ctx context.Context, id int64) error
+	AddFavoriteFunc           func(ctx context.Context, arg db.AddFavoriteParams) error
+	RemoveFavoriteFunc        func(ctx context.Context, arg db.RemoveFavoriteParams) error
+	GetFavoriteSpotIDsFunc    func(ctx context.Context, arg db.GetFavoriteSpotIDsParams) ([]int64, error)
+	GetFavoriteSpotsFunc      func(ctx context.Context, userID int64) ([]db.GetFavoriteSpotsRow, error)
+	DeleteImagesBySpotIDFunc  func(ctx context.Context, spotID int64) ([]db.Image, error)
+
+	GetOpeningHoursBySpotIDsFunc           func(ctx context.Context, spotIds []int64) ([]db.OpeningHour, error)
+	InsertOpeningHoursFunc                 func(ctx context.Context, arg db.InsertOpeningHoursParams) error
+	DeleteOpeningHoursFunc                 func(ctx context.Context, spotID int64) error
+	GetOpeningHoursExceptionsBySpotIDsFunc func(ctx context.Context, arg db.GetOpeningHoursExceptionsBySpotIDsParams) ([]db.OpeningHoursException, error)
+	InsertOpeningHoursExceptionFunc        func(ctx context.Context, arg db.InsertOpeningHoursExceptionParams) error
+	DeleteOpeningHoursExceptionsFunc       func(ctx context.Context, spotID int64) error
+
+	GetSpotEquipmentFunc            func(ctx context.Context, spotID int64) ([]db.SpotEquipment, error)
+	UpsertSpotEquipmentFunc         func(ctx context.Context, arg db.UpsertSpotEquipmentParams) (db.SpotEquipment, error)
+	DeleteSpotEquipmentFunc         func(ctx context.Context, arg db.DeleteSpotEquipmentParams) (db.SpotEquipment, error)
+	DeleteSpotEquipmentBySpotIDFunc func(ctx context.Context, spotID int64) error
 }
 
 func (m MockSpotQueries) GetSpotByID(ctx context.Context, id int64) (db.Spot, error) {
 	return m.GetSpotByIDFunc(ctx, id)
 }
 
+func (m MockSpotQueries) GetSpotByIDForUpdate(ctx context.Context, id int64) (db.Spot, error) {
+	return m.GetSpotByIDForUpdateFunc(ctx, id)
+}
+
 func (m MockSpotQueries) GetAllSpots(ctx context.Context) ([]db.Spot, error) {
 	return m.GetAllSpotsFunc(ctx)
 }
@@ -27,18 +64,139 @@ func (m MockSpotQueries) InsertSpot(ctx context.Context, arg db.InsertSpotParams
 	return m.InsertSpotFunc(ctx, arg)
 }
 
-func (m MockSpotQueries) GetPublicSpotsWithDetails(ctx context.Context) ([]db.GetPublicSpotsWithDetailsRow, error) {
-	return m.GetPublicSpotsWithDetailsFunc(ctx)
+func (m MockSpotQueries) ListSpotsByID(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+	return m.ListSpotsByIDFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) ListSpotsByName(ctx context.Context, arg db.ListSpotsByNameParams) ([]db.ListSpotsByNameRow, error) {
+	return m.ListSpotsByNameFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) ListSpotsByNewest(ctx context.Context, arg db.ListSpotsByNewestParams) ([]db.ListSpotsByNewestRow, error) {
+	return m.ListSpotsByNewestFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) CountSpots(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+	return m.CountSpotsFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) GetSpotsInBBox(ctx context.Context, arg db.GetSpotsInBBoxParams) ([]db.GetSpotsInBBoxRow, error) {
+	return m.GetSpotsInBBoxFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) GetSpotClustersInBBox(ctx context.Context, arg db.GetSpotClustersInBBoxParams) ([]db.GetSpotClustersInBBoxRow, error) {
+	return m.GetSpotClustersInBBoxFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) GetSpotsNearby(ctx context.Context, arg db.GetSpotsNearbyParams) ([]db.GetSpotsNearbyRow, error) {
+	return m.GetSpotsNearbyFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) SearchSpots(ctx context.Context, arg db.SearchSpotsParams) ([]db.SearchSpotsRow, error) {
+	return m.SearchSpotsFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) UpdateSpot(ctx context.Context, arg db.UpdateSpotParams) (db.Spot, error) {
+	return m.UpdateSpotFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) DeleteSpot(ctx context.Context, id int64) (db.Spot, error) {
+	return m.DeleteSpotFunc(ctx, id)
+}
+
+func (m MockSpotQueries) InsertLocation(ctx context.Context, arg db.InsertLocationParams) (db.Location, error) {
+	return m.InsertLocationFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) GetLocationByID(ctx context.Context, id int64) (db.Location, error) {
+	return m.GetLocationByIDFunc(ctx, id)
+}
+
+func (m MockSpotQueries) UpdateLocation(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error) {
+	return m.UpdateLocationFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) DeleteLocation(ctx context.Context, id int64) error {
+	return m.DeleteLocationFunc(ctx, id)
+}
+
+func (m MockSpotQueries) DeleteImagesBySpotID(ctx context.Context, spotID int64) ([]db.Image, error) {
+	return m.DeleteImagesBySpotIDFunc(ctx, spotID)
+}
+
+// ExecTx has no real transaction, it simply runs fn against the mock itself
+func (m MockSpotQueries) ExecTx(ctx context.Context, fn func(interfaces.SpotQueries) error) error {
+	return fn(m)
+}
+
+func (m MockSpotQueries) AddFavorite(ctx context.Context, arg db.AddFavoriteParams) error {
+	return m.AddFavoriteFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) RemoveFavorite(ctx context.Context, arg db.RemoveFavoriteParams) error {
+	return m.RemoveFavoriteFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) GetFavoriteSpotIDs(ctx context.Context, arg db.GetFavoriteSpotIDsParams) ([]int64, error) {
+	return m.GetFavoriteSpotIDsFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) GetFavoriteSpots(ctx context.Context, userID int64) ([]db.GetFavoriteSpotsRow, error) {
+	return m.GetFavoriteSpotsFunc(ctx, userID)
+}
+
+func (m MockSpotQueries) GetOpeningHoursBySpotIDs(ctx context.Context, spotIds []int64) ([]db.OpeningHour, error) {
+	return m.GetOpeningHoursBySpotIDsFunc(ctx, spotIds)
+}
+
+func (m MockSpotQueries) InsertOpeningHours(ctx context.Context, arg db.InsertOpeningHoursParams) error {
+	return m.InsertOpeningHoursFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) DeleteOpeningHours(ctx context.Context, spotID int64) error {
+	return m.DeleteOpeningHoursFunc(ctx, spotID)
+}
+
+func (m MockSpotQueries) GetOpeningHoursExceptionsBySpotIDs(ctx context.Context, arg db.GetOpeningHoursExceptionsBySpotIDsParams) ([]db.OpeningHoursException, error) {
+	return m.GetOpeningHoursExceptionsBySpotIDsFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) InsertOpeningHoursException(ctx context.Context, arg db.InsertOpeningHoursExceptionParams) error {
+	return m.InsertOpeningHoursExceptionFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) DeleteOpeningHoursExceptions(ctx context.Context, spotID int64) error {
+	return m.DeleteOpeningHoursExceptionsFunc(ctx, spotID)
+}
+
+func (m MockSpotQueries) GetSpotEquipment(ctx context.Context, spotID int64) ([]db.SpotEquipment, error) {
+	return m.GetSpotEquipmentFunc(ctx, spotID)
+}
+
+func (m MockSpotQueries) UpsertSpotEquipment(ctx context.Context, arg db.UpsertSpotEquipmentParams) (db.SpotEquipment, error) {
+	return m.UpsertSpotEquipmentFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) DeleteSpotEquipment(ctx context.Context, arg db.DeleteSpotEquipmentParams) (db.SpotEquipment, error) {
+	return m.DeleteSpotEquipmentFunc(ctx, arg)
+}
+
+func (m MockSpotQueries) DeleteSpotEquipmentBySpotID(ctx context.Context, spotID int64) error {
+	return m.DeleteSpotEquipmentBySpotIDFunc(ctx, spotID)
+}
+
+func (m MockSpotQueries) GetCategories(ctx context.Context) ([]db.Category, error) {
+	return m.GetCategoriesFunc(ctx)
 }
 
-func (m MockSpotQueries) GetSpotsWithDetails(ctx context.Context) ([]db.GetSpotsWithDetailsRow, error) {
-	return m.GetSpotsWithDetailsFunc(ctx)
+func (m MockSpotQueries) GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error) {
+	return m.GetCategoryBySlugFunc(ctx, slug)
 }
 
-func (m MockSpotQueries) GetPublicSpotsByCategoryWithDetails(ctx context.Context, category db.SpotCategory) ([]db.GetPublicSpotsByCategoryWithDetailsRow, error) {
-	return m.GetPublicSpotsByCategoryWithDetailsFunc(ctx, category)
+func (m MockSpotQueries) ExportSpotsEach(ctx context.Context, arg db.ExportSpotsParams, fn func(db.ExportSpotsRow) error) error {
+	return m.ExportSpotsEachFunc(ctx, arg, fn)
 }
 
-func (m MockSpotQueries) GetSpotsByCategoryWithDetails(ctx context.Context, category db.SpotCategory) ([]db.GetSpotsByCategoryWithDetailsRow, error) {
-	return m.GetSpotsByCategoryWithDetailsFunc(ctx, category)
+func (m MockSpotQueries) GetDuplicateSpotID(ctx context.Context, arg db.GetDuplicateSpotIDParams) (int64, error) {
+	return m.GetDuplicateSpotIDFunc(ctx, arg)
 }
@@ -0,0 +1,90 @@
+package mocks
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/interfaces"
+	"context"
+)
+
+type MockSubmissionQueries struct {
+	InsertSubmissionFunc           func(ctx context.Context, arg db.InsertSubmissionParams) (db.SpotSubmission, error)
+	GetSubmissionByIDFunc          func(ctx context.Context, id int64) (db.SpotSubmission, error)
+	GetSubmissionByIDForUpdateFunc func(ctx context.Context, id int64) (db.SpotSubmission, error)
+	GetSubmissionsByStatusFunc     func(ctx context.Context, status db.SubmissionStatus) ([]db.SpotSubmission, error)
+	GetSubmissionsByUserIDFunc     func(ctx context.Context, userID int64) ([]db.SpotSubmission, error)
+	UpdateSubmissionFunc           func(ctx context.Context, arg db.UpdateSubmissionParams) (db.SpotSubmission, error)
+	ApproveSubmissionFunc          func(ctx context.Context, arg db.ApproveSubmissionParams) (db.SpotSubmission, error)
+	RejectSubmissionFunc           func(ctx context.Context, arg db.RejectSubmissionParams) (db.SpotSubmission, error)
+	InsertSubmissionImageFunc      func(ctx context.Context, arg db.InsertSubmissionImageParams) (db.SubmissionImage, error)
+	GetSubmissionImagesFunc        func(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error)
+	DeleteSubmissionImagesFunc     func(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error)
+	MoveSubmissionImagesToSpotFunc func(ctx context.Context, arg db.MoveSubmissionImagesToSpotParams) error
+	GetCategoryBySlugFunc          func(ctx context.Context, slug string) (db.Category, error)
+	InsertLocationFunc             func(ctx context.Context, arg db.InsertLocationParams) (db.Location, error)
+	InsertSpotFunc                 func(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error)
+}
+
+func (m *MockSubmissionQueries) InsertSubmission(ctx context.Context, arg db.InsertSubmissionParams) (db.SpotSubmission, error) {
+	return m.InsertSubmissionFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) GetSubmissionByID(ctx context.Context, id int64) (db.SpotSubmission, error) {
+	return m.GetSubmissionByIDFunc(ctx, id)
+}
+
+func (m *MockSubmissionQueries) GetSubmissionByIDForUpdate(ctx context.Context, id int64) (db.SpotSubmission, error) {
+	return m.GetSubmissionByIDForUpdateFunc(ctx, id)
+}
+
+func (m *MockSubmissionQueries) GetSubmissionsByStatus(ctx context.Context, status db.SubmissionStatus) ([]db.SpotSubmission, error) {
+	return m.GetSubmissionsByStatusFunc(ctx, status)
+}
+
+func (m *MockSubmissionQueries) GetSubmissionsByUserID(ctx context.Context, userID int64) ([]db.SpotSubmission, error) {
+	return m.GetSubmissionsByUserIDFunc(ctx, userID)
+}
+
+func (m *MockSubmissionQueries) UpdateSubmission(ctx context.Context, arg db.UpdateSubmissionParams) (db.SpotSubmission, error) {
+	return m.UpdateSubmissionFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) ApproveSubmission(ctx context.Context, arg db.ApproveSubmissionParams) (db.SpotSubmission, error) {
+	return m.ApproveSubmissionFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) RejectSubmission(ctx context.Context, arg db.RejectSubmissionParams) (db.SpotSubmission, error) {
+	return m.RejectSubmissionFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) InsertSubmissionImage(ctx context.Context, arg db.InsertSubmissionImageParams) (db.SubmissionImage, error) {
+	return m.InsertSubmissionImageFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) GetSubmissionImages(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error) {
+	return m.GetSubmissionImagesFunc(ctx, submissionID)
+}
+
+func (m *MockSubmissionQueries) DeleteSubmissionImages(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error) {
+	return m.DeleteSubmissionImagesFunc(ctx, submissionID)
+}
+
+func (m *MockSubmissionQueries) MoveSubmissionImagesToSpot(ctx context.Context, arg db.MoveSubmissionImagesToSpotParams) error {
+	return m.MoveSubmissionImagesToSpotFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) InsertLocation(ctx context.Context, arg db.InsertLocationParams) (db.Location, error) {
+	return m.InsertLocationFunc(ctx, arg)
+}
+
+func (m *MockSubmissionQueries) InsertSpot(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error) {
+	return m.InsertSpotFunc(ctx, arg)
+}
+
+// ExecSubmissionTx has no real transaction, it simply runs fn against the mock itself
+func (m *MockSubmissionQueries) ExecSubmissionTx(ctx context.Context, fn func(interfaces.SubmissionQueries) error) error {
+	return fn(m)
+}
+
+func (m *MockSubmissionQueries) GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error) {
+	return m.GetCategoryBySlugFunc(ctx, slug)
+}
@@ -6,10 +6,11 @@ import (
 )
 
 type MockUserQueries struct {
-	GetUserByIDFunc    func(ctx context.Context, id int64) (db.User, error)
-	GetUserByEmailFunc func(ctx context.Context, email string) (db.User, error)
-	GetAllUsersFunc    func(ctx context.Context) ([]db.User, error)
-	InsertUserFunc     func(ctx context.Context, arg db.InsertUserParams) (db.User, error)
+	GetUserByIDFunc        func(ctx context.Context, id int64) (db.User, error)
+	GetUserByEmailFunc     func(ctx context.Context, email string) (db.User, error)
+	GetAllUsersFunc        func(ctx context.Context) ([]db.User, error)
+	InsertUserFunc         func(ctx context.Context, arg db.InsertUserParams) (db.User, error)
+	PromoteUserToAdminFunc func(ctx context.Context, email string) (db.User, error)
 }
 
 func (m *MockUserQueries) GetUserByID(ctx context.Context, id int64) (db.User, error) {
@@ -24,3 +25,6 @@ func (m *MockUserQueries) GetAllUsers(ctx context.Context) ([]db.User, error) {
 func (m *MockUserQueries) InsertUser(ctx context.Context, arg db.InsertUserParams) (db.User, error) {
 	return m.InsertUserFunc(ctx, arg)
 }
+func (m *MockUserQueries) PromoteUserToAdmin(ctx context.Context, email string) (db.User, error) {
+	return m.PromoteUserToAdminFunc(ctx, email)
+}
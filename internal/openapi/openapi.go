@@ -0,0 +1,240 @@
+// Package openapi describes the HTTP API as an OpenAPI 3.0 document. The schemas
+// are generated from the request and response types, so they follow the code.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+
+	// securityScheme is the session cookie set by /login
+	securityScheme = "cookieAuth"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// sharedResponses are the error responses, referenced by status. Handlers answer
+// with a translated plain text message, the auth middleware with a JSON error.
+var sharedResponses = map[int]string{
+	http.StatusBadRequest:            "BadRequest",
+	http.StatusUnauthorized:          "Unauthorized",
+	http.StatusForbidden:             "Forbidden",
+	http.StatusNotFound:              "NotFound",
+	http.StatusConflict:              "Conflict",
+	http.StatusRequestEntityTooLarge: "PayloadTooLarge",
+	http.StatusInternalServerError:   "InternalError",
+}
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Build describes every operation of the API
+func Build() *Document {
+	schemas := newSchemaBuilder()
+	schemas.components["Error"] = &Schema{
+		Type:       "object",
+		Required:   []string{"error"},
+		Properties: map[string]*Schema{"error": {Type: "string"}},
+	}
+
+	doc := &Document{
+		OpenAPI: openAPIVersion,
+		Info: Info{
+			Title:   "Pilaite API",
+			Version: "1.0.0",
+			Description: "Spots around Pilaitė with their photos, reviews, opening hours and equipment. " +
+				"Messages are translated into the language of the session or Accept-Language.",
+		},
+		Tags:  tags,
+		Paths: map[string]PathItem{},
+		Components: Components{
+			Schemas:   schemas.components,
+			Responses: errorResponses(),
+			SecuritySchemes: map[string]*SecurityScheme{
+				securityScheme: {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        "session_id",
+					Description: "Set by POST /login",
+				},
+			},
+		},
+	}
+
+	for _, op := range operations {
+		item, ok := doc.Paths[op.path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[op.path] = item
+		}
+		method := strings.ToLower(op.method)
+		if _, exists := item[method]; exists {
+			panic(fmt.Sprintf("openapi: %s %s is described twice", op.method, op.path))
+		}
+		item[method] = op.build(schemas)
+	}
+	return doc
+}
+
+func errorResponses() map[string]*Response {
+	plain := map[string]*MediaType{"text/plain": {Schema: &Schema{Type: "string"}}}
+	withJSON := map[string]*MediaType{
+		"application/json": {Schema: &Schema{Ref: "#/components/schemas/Error"}},
+		"text/plain":       {Schema: &Schema{Type: "string"}},
+	}
+
+	responses := map[string]*Response{}
+	for status, name := range sharedResponses {
+		content := plain
+		if status == http.StatusUnauthorized || status == http.StatusForbidden {
+			// The auth middleware answers with JSON, handlers with text
+			content = withJSON
+		}
+		responses[name] = &Response{Description: http.StatusText(status), Content: content}
+	}
+	return responses
+}
+
+func (op operation) build(schemas *schemaBuilder) *Operation {
+	built := &Operation{
+		Tags:        []string{op.tag},
+		Summary:     op.summary,
+		Description: op.description,
+		OperationID: operationID(op.method, op.path),
+		Responses:   map[string]*Response{},
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(op.path, -1) {
+		known, ok := pathParams[match[1]]
+		if !ok {
+			panic(fmt.Sprintf("openapi: unknown path parameter %q in %s", match[1], op.path))
+		}
+		param := *known
+		param.Name, param.In, param.Required = match[1], "path", true
+		built.Parameters = append(built.Parameters, &param)
+	}
+	built.Parameters = append(built.Parameters, op.query...)
+
+	switch {
+	case op.body != nil:
+		built.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: schemaOf(schemas, op.body)}},
+		}
+	case op.upload != "":
+		built.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Required:   []string{op.upload},
+				Properties: map[string]*Schema{op.upload: {Type: "string", Format: "binary"}},
+			}}},
+		}
+	}
+
+	for _, resp := range op.responses {
+		description := resp.description
+		if description == "" {
+			description = http.StatusText(resp.status)
+		}
+		built.Responses[strconv.Itoa(resp.status)] = &Response{
+			Description: description,
+			Content:     resp.content(schemas),
+		}
+	}
+
+	errors := append([]int(nil), op.errors...)
+	switch op.access {
+	case session:
+		errors = append(errors, http.StatusUnauthorized)
+		built.Security = []map[string][]string{{securityScheme: {}}}
+	case admin:
+		errors = append(errors, http.StatusUnauthorized, http.StatusForbidden)
+		built.Security = []map[string][]string{{securityScheme: {}}}
+	case optionalSession:
+		// An empty requirement makes the cookie optional
+		built.Security = []map[string][]string{{}, {securityScheme: {}}}
+	case guest:
+		errors = append(errors, http.StatusForbidden)
+	}
+	if !op.noServerError {
+		errors = append(errors, http.StatusInternalServerError)
+	}
+	for _, status := range errors {
+		name, ok := sharedResponses[status]
+		if !ok {
+			panic(fmt.Sprintf("openapi: no shared response for %d", status))
+		}
+		built.Responses[strconv.Itoa(status)] = &Response{Ref: "#/components/responses/" + name}
+	}
+	return built
+}
+
+func (resp response) content(schemas *schemaBuilder) map[string]*MediaType {
+	if resp.body == nil {
+		return nil
+	}
+	contentType := resp.contentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	return map[string]*MediaType{contentType: {Schema: schemaOf(schemas, resp.body)}}
+}
+
+// schemaOf uses hand written schemas as they are and reflects on anything else
+func schemaOf(schemas *schemaBuilder, v any) *Schema {
+	if s, ok := v.(*Schema); ok {
+		return s
+	}
+	return schemas.of(v)
+}
+
+// operationID turns "GET /spots/{id}/reviews" into "getSpotsIdReviews"
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range path {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SpecHandler serves the document as JSON. It is built once, up front, so a type the
+// builder can't describe fails at startup rather than on the first request.
+func SpecHandler() http.HandlerFunc {
+	spec, err := json.Marshal(Build())
+	if err != nil {
+		panic(fmt.Sprintf("openapi: %v", err))
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	}
+}
+
+// DocsHandler serves a page that renders /openapi.json, it loads nothing else so it
+// works offline
+func DocsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(docsPage)
+	}
+}
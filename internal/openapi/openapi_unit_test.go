@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"PilaiteProject/internal/db"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuild_ReferencesResolve(t *testing.T) {
+	doc := Build()
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var raw any
+	if err := json.Unmarshal(spec, &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				name, found := strings.CutPrefix(ref, "#/components/schemas/")
+				_, ok := doc.Components.Schemas[name]
+				if !found {
+					name, found = strings.CutPrefix(ref, "#/components/responses/")
+					_, ok = doc.Components.Responses[name]
+				}
+				if !found || !ok {
+					t.Errorf("unresolved reference %s", ref)
+				}
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(raw)
+}
+
+func TestBuild_Operations(t *testing.T) {
+	doc := Build()
+	ids := map[string]string{}
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			key := method + " " + path
+			if other, ok := ids[op.OperationID]; ok {
+				t.Errorf("%s and %s share the operation ID %s", key, other, op.OperationID)
+			}
+			ids[op.OperationID] = key
+			if op.Summary == "" || len(op.Responses) == 0 {
+				t.Errorf("%s needs a summary and responses", key)
+			}
+		}
+	}
+
+	login := doc.Paths["/login"]["post"]
+	if login.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/LoginRequest" {
+		t.Fatalf("unexpected login body: %+v", login.RequestBody.Content["application/json"].Schema)
+	}
+	if login.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/AuthResponse" {
+		t.Fatalf("unexpected login response: %+v", login.Responses["200"])
+	}
+	if login.Security != nil || login.Responses["403"] == nil {
+		t.Fatalf("login is for guests: %+v", login)
+	}
+
+	deleteSpot := doc.Paths["/spots/{id}"]["delete"]
+	if len(deleteSpot.Security) != 1 || deleteSpot.Responses["401"] == nil || deleteSpot.Responses["403"] == nil {
+		t.Fatalf("deleting a spot needs an admin: %+v", deleteSpot)
+	}
+	if id := deleteSpot.Parameters[0]; id.Name != "id" || id.In != "path" || !id.Required || id.Schema.Type != "integer" {
+		t.Fatalf("unexpected id parameter: %+v", id)
+	}
+}
+
+type embedded struct {
+	Shared string `json:"shared"`
+}
+
+type example struct {
+	embedded
+	Name     string          `json:"name"`
+	Optional *float64        `json:"optional,omitempty"`
+	Nested   *example        `json:"nested"`
+	When     time.Time       `json:"when"`
+	Role     db.UserRole     `json:"role"`
+	Tags     []string        `json:"tags"`
+	Counts   map[string]int  `json:"counts"`
+	Hidden   string          `json:"-"`
+	Raw      json.RawMessage `json:"raw,omitempty"`
+	private  string
+}
+
+func TestSchemaBuilder(t *testing.T) {
+	b := newSchemaBuilder()
+	ref := b.of(example{})
+	if ref.Ref != "#/components/schemas/example" {
+		t.Fatalf("expected a reference, got %+v", ref)
+	}
+
+	s := b.components["example"]
+	wantRequired := []string{"shared", "name", "nested", "when", "role", "tags", "counts"}
+	if !slices.Equal(s.Required, wantRequired) {
+		t.Fatalf("expected required %v, got %v", wantRequired, s.Required)
+	}
+	for _, name := range []string{"Hidden", "private", "embedded"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("%s should not be a property", name)
+		}
+	}
+
+	if p := s.Properties["optional"]; p.Type != "number" || !p.Nullable {
+		t.Errorf("unexpected optional: %+v", p)
+	}
+	if p := s.Properties["nested"]; !p.Nullable || len(p.AllOf) != 1 || p.AllOf[0].Ref != ref.Ref {
+		t.Errorf("unexpected nested: %+v", p)
+	}
+	if p := s.Properties["when"]; p.Format != "date-time" {
+		t.Errorf("unexpected when: %+v", p)
+	}
+	if p := s.Properties["role"]; !slices.Equal(p.Enum, enumValues(db.AllUserRoleValues())) {
+		t.Errorf("unexpected role: %+v", p)
+	}
+	if p := s.Properties["counts"]; p.AdditionalProperties == nil || p.AdditionalProperties.Type != "integer" {
+		t.Errorf("unexpected counts: %+v", p)
+	}
+}
@@ -0,0 +1,667 @@
+package openapi
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/export"
+	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/migrate"
+	"encoding/json"
+	"net/http"
+)
+
+// access is what a route needs from the session, it decides the security and the
+// 401 and 403 responses of an operation
+type access int
+
+const (
+	public access = iota
+	// optionalSession routes answer everyone, a session adds secret spots or favorites
+	optionalSession
+	session
+	admin
+	// guest routes are refused with 403 when already logged in
+	guest
+)
+
+type operation struct {
+	method      string
+	path        string
+	tag         string
+	summary     string
+	description string
+	access      access
+	query       []*Parameter
+	// body is a value of the JSON request body type
+	body any
+	// upload is the multipart field of a file upload
+	upload    string
+	responses []response
+	// errors are the statuses answered with a message besides those of access, 500
+	// is added unless noServerError is set
+	errors        []int
+	noServerError bool
+}
+
+type response struct {
+	status      int
+	description string
+	// body is a value of the response type, nil for an empty body
+	body any
+	// contentType defaults to application/json
+	contentType string
+}
+
+const (
+	tagAuth        = "auth"
+	tagSpots       = "spots"
+	tagExport      = "export"
+	tagSubmissions = "submissions"
+	tagCategories  = "categories"
+	tagAdmin       = "admin"
+	tagHealth      = "health"
+)
+
+var tags = []Tag{
+	{Name: tagAuth, Description: "Registration, login and the language of responses"},
+	{Name: tagSpots, Description: "Spots with their photos, reviews, opening hours and equipment"},
+	{Name: tagExport, Description: "Map files of the visible spots"},
+	{Name: tagSubmissions, Description: "Spots proposed by users and their moderation"},
+	{Name: tagCategories, Description: "Spot categories"},
+	{Name: tagAdmin, Description: "Maintenance for admins"},
+	{Name: tagHealth, Description: "Probes and API documentation"},
+}
+
+// pathParams describes the chi URL parameters by name
+var pathParams = map[string]*Parameter{
+	"id":       {Description: "Spot or submission ID", Schema: &Schema{Type: "integer", Format: "int64"}},
+	"imageId":  {Description: "Image ID", Schema: &Schema{Type: "integer", Format: "int64"}},
+	"reviewId": {Description: "Review ID", Schema: &Schema{Type: "integer", Format: "int64"}},
+	"category": {Description: "Category slug", Schema: &Schema{Type: "string"}},
+	"slug":     {Description: "Category slug", Schema: &Schema{Type: "string"}},
+	"type":     {Description: "Equipment type", Schema: &Schema{Type: "string", Enum: enumValues(db.AllEquipmentTypeValues())}},
+}
+
+func queryParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func requiredQueryParam(name, description string, schema *Schema) *Parameter {
+	p := queryParam(name, description, schema)
+	p.Required = true
+	return p
+}
+
+var (
+	number  = &Schema{Type: "number", Format: "double"}
+	integer = &Schema{Type: "integer"}
+	boolean = &Schema{Type: "boolean"}
+	text    = &Schema{Type: "string"}
+	list    = &Schema{Type: "array", Items: text}
+
+	listQuery = []*Parameter{
+		queryParam("category", "Category slugs, repeated or comma separated", list),
+		queryParam("equipment", "Equipment types the spot must have, repeated or comma separated",
+			&Schema{Type: "array", Items: &Schema{Type: "string", Enum: enumValues(db.AllEquipmentTypeValues())}}),
+		queryParam("sort", "Order of the list, defaults to id", &Schema{Type: "string", Enum: []string{"id", "name", "newest"}}),
+		queryParam("limit", "Page size", integer),
+		queryParam("cursor", "next_cursor of the previous page", text),
+		queryParam("open_now", "Only spots open right now, not together with open_at", boolean),
+		queryParam("open_at", "Only spots open at this RFC 3339 time", &Schema{Type: "string", Format: "date-time"}),
+	}
+
+	bboxQuery = []*Parameter{
+		requiredQueryParam("minLat", "South edge", number),
+		requiredQueryParam("minLng", "West edge", number),
+		requiredQueryParam("maxLat", "North edge", number),
+		requiredQueryParam("maxLng", "East edge", number),
+	}
+
+	exportQuery = []*Parameter{
+		queryParam("category", "Category slugs, repeated or comma separated", list),
+		queryParam("minLat", "South edge, the bounding box needs all four edges", number),
+		queryParam("minLng", "West edge", number),
+		queryParam("maxLat", "North edge", number),
+		queryParam("maxLng", "East edge", number),
+	}
+
+	// featureCollection is the outline of a GeoJSON export
+	featureCollection = &Schema{
+		Type:     "object",
+		Required: []string{"type", "features"},
+		Properties: map[string]*Schema{
+			"type":     {Type: "string", Enum: []string{"FeatureCollection"}},
+			"features": {Type: "array", Items: &Schema{Type: "object", Description: "A Point feature per spot"}},
+		},
+	}
+)
+
+var (
+	spotPage    = dto.SpotPageDTO{}
+	spotDetail  = dto.SpotDetailDTO{}
+	submission  = dto.SubmissionDTO{}
+	importReply = dto.SpotImportReportDTO{}
+	health      = dto.HealthDTO{}
+)
+
+var operations = []operation{
+	// Auth
+	{
+		method: http.MethodPost, path: "/register", tag: tagAuth, access: guest,
+		summary: "Register a user",
+		body:    handler.RegisterRequest{},
+		responses: []response{
+			{status: http.StatusCreated, body: handler.AuthResponse{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusConflict},
+	},
+	{
+		method: http.MethodPost, path: "/login", tag: tagAuth, access: guest,
+		summary:     "Log in",
+		description: "Sets the session_id cookie used by every route that needs a session.",
+		body:        handler.LoginRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: handler.AuthResponse{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusUnauthorized},
+	},
+	{
+		method: http.MethodGet, path: "/me", tag: tagAuth, access: session,
+		summary: "Get the logged in user",
+		responses: []response{
+			{status: http.StatusOK, body: handler.UserDTO{}},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/logout", tag: tagAuth, access: session,
+		summary: "Log out",
+		responses: []response{
+			{status: http.StatusOK, body: handler.AuthResponse{}},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/language", tag: tagAuth, access: public,
+		summary:     "Get the language of responses",
+		description: "The saved preference of the session, otherwise the best match for Accept-Language.",
+		responses: []response{
+			{status: http.StatusOK, body: handler.LanguageResponse{}},
+		},
+		noServerError: true,
+	},
+	{
+		method: http.MethodPut, path: "/me/language", tag: tagAuth, access: session,
+		summary: "Save the language of responses",
+		body:    handler.LanguageRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: handler.LanguageResponse{}},
+			{status: http.StatusNoContent, description: "An empty language removed the preference"},
+		},
+		errors:        []int{http.StatusBadRequest},
+		noServerError: true,
+	},
+	{
+		method: http.MethodGet, path: "/me/favorites", tag: tagSpots, access: session,
+		summary: "List the favorite spots of the user",
+		responses: []response{
+			{status: http.StatusOK, body: []dto.SpotCardDTO{}},
+		},
+	},
+
+	// Spots
+	{
+		method: http.MethodGet, path: "/spots/", tag: tagSpots, access: optionalSession,
+		summary: "List public spots",
+		query:   listQuery,
+		responses: []response{
+			{status: http.StatusOK, body: spotPage},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/all", tag: tagSpots, access: session,
+		summary: "List spots including secret ones",
+		query:   listQuery,
+		responses: []response{
+			{status: http.StatusOK, body: spotPage},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/public/category/{category}", tag: tagSpots, access: optionalSession,
+		summary: "List public spots of a category",
+		query:   listQuery,
+		responses: []response{
+			{status: http.StatusOK, body: spotPage},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/category/{category}", tag: tagSpots, access: session,
+		summary: "List spots of a category including secret ones",
+		query:   listQuery,
+		responses: []response{
+			{status: http.StatusOK, body: spotPage},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/nearby", tag: tagSpots, access: optionalSession,
+		summary: "List spots around a point, nearest first",
+		query: []*Parameter{
+			requiredQueryParam("lat", "Latitude", number),
+			requiredQueryParam("lng", "Longitude", number),
+			queryParam("radius", "Radius in meters", number),
+		},
+		responses: []response{
+			{status: http.StatusOK, body: []dto.SpotCardDTO{}},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/spots/bbox", tag: tagSpots, access: optionalSession,
+		summary:     "Get the spots of a map viewport",
+		description: "Low zoom levels get clusters with a category breakdown instead of single spots.",
+		query:       append(append([]*Parameter(nil), bboxQuery...), requiredQueryParam("zoom", "Map zoom level", integer)),
+		responses: []response{
+			{status: http.StatusOK, body: dto.SpotMapDTO{}},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/spots/search", tag: tagSpots, access: optionalSession,
+		summary: "Search spots by text",
+		query: []*Parameter{
+			queryParam("q", "Search terms", text),
+		},
+		responses: []response{
+			{status: http.StatusOK, body: []dto.SpotSearchResultDTO{}},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodPost, path: "/spots/", tag: tagSpots, access: admin,
+		summary: "Create a spot",
+		body:    handler.SpotRequest{},
+		responses: []response{
+			{status: http.StatusCreated, body: spotDetail},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/spots/import", tag: tagSpots, access: admin,
+		summary:     "Import spots from a CSV or GeoJSON file",
+		description: "Nothing is imported when a row has an error. Spots that already exist are skipped.",
+		upload:      "file",
+		query: []*Parameter{
+			queryParam("format", "File format, taken from the file extension when missing", &Schema{Type: "string", Enum: []string{"csv", "geojson"}}),
+			queryParam("dry_run", "Only validate the file", boolean),
+		},
+		responses: []response{
+			{status: http.StatusOK, description: "Dry run report", body: importReply},
+			{status: http.StatusCreated, description: "The spots were imported", body: importReply},
+			{status: http.StatusUnprocessableEntity, description: "Rows have errors, nothing was imported", body: importReply},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge},
+	},
+	{
+		method: http.MethodGet, path: "/spots/{id}", tag: tagSpots, access: public,
+		summary: "Get a spot",
+		responses: []response{
+			{status: http.StatusOK, body: db.Spot{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/spots/{id}", tag: tagSpots, access: admin,
+		summary: "Replace a spot",
+		body:    handler.SpotRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: spotDetail},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPatch, path: "/spots/{id}", tag: tagSpots, access: admin,
+		summary: "Update some fields of a spot",
+		body:    handler.PatchSpotRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: spotDetail},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/spots/{id}", tag: tagSpots, access: admin,
+		summary: "Delete a spot with its photos",
+		responses: []response{
+			{status: http.StatusNoContent},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/{id}/images", tag: tagSpots, access: session,
+		summary: "List the photos of a spot",
+		responses: []response{
+			{status: http.StatusOK, body: []dto.ImageDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/spots/{id}/images", tag: tagSpots, access: session,
+		summary: "Upload a photo of a spot",
+		upload:  "image",
+		responses: []response{
+			{status: http.StatusCreated, body: dto.ImageDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge},
+	},
+	{
+		method: http.MethodDelete, path: "/spots/{id}/images/{imageId}", tag: tagSpots, access: admin,
+		summary: "Delete a photo",
+		responses: []response{
+			{status: http.StatusNoContent},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/{id}/images/{imageId}/metadata", tag: tagSpots, access: admin,
+		summary:     "Get the metadata stripped from a photo",
+		description: "Only kept when the server runs with KEEP_IMAGE_METADATA.",
+		responses: []response{
+			{status: http.StatusOK, body: json.RawMessage{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/{id}/reviews", tag: tagSpots, access: optionalSession,
+		summary: "List the reviews of a spot",
+		responses: []response{
+			{status: http.StatusOK, body: dto.SpotReviewsDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/spots/{id}/reviews", tag: tagSpots, access: session,
+		summary: "Review a spot",
+		body:    handler.ReviewRequest{},
+		responses: []response{
+			{status: http.StatusCreated, body: dto.ReviewDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodPut, path: "/spots/{id}/reviews/{reviewId}", tag: tagSpots, access: session,
+		summary: "Edit an own review",
+		body:    handler.ReviewRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: dto.ReviewDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/spots/{id}/reviews/{reviewId}", tag: tagSpots, access: session,
+		summary: "Delete a review, admins may delete any",
+		responses: []response{
+			{status: http.StatusNoContent},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/spots/{id}/favorite", tag: tagSpots, access: session,
+		summary: "Save a spot as favorite",
+		responses: []response{
+			{status: http.StatusNoContent},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/spots/{id}/favorite", tag: tagSpots, access: session,
+		summary: "Remove a spot from the favorites",
+		responses: []response{
+			{status: http.StatusNoContent},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/spots/{id}/hours", tag: tagSpots, access: optionalSession,
+		summary: "Get the opening hours of a spot",
+		responses: []response{
+			{status: http.StatusOK, body: dto.OpeningHoursDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/spots/{id}/hours", tag: tagSpots, access: admin,
+		summary:     "Replace the opening hours of a spot",
+		description: "Times are HH:MM in Europe/Vilnius, weekday 1 is Monday.",
+		body:        handler.OpeningHoursRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: dto.OpeningHoursDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/{id}/equipment", tag: tagSpots, access: optionalSession,
+		summary: "List the equipment of an outdoor gym",
+		responses: []response{
+			{status: http.StatusOK, body: []dto.EquipmentDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/spots/{id}/equipment/{type}", tag: tagSpots, access: admin,
+		summary: "Set one kind of equipment of a spot",
+		body:    handler.EquipmentRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: dto.EquipmentDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/spots/{id}/equipment/{type}", tag: tagSpots, access: admin,
+		summary: "Remove one kind of equipment from a spot",
+		responses: []response{
+			{status: http.StatusNoContent},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Export
+	{
+		method: http.MethodGet, path: "/spots.geojson", tag: tagExport, access: optionalSession,
+		summary: "Export spots as GeoJSON",
+		query:   exportQuery,
+		responses: []response{
+			{status: http.StatusOK, body: featureCollection, contentType: export.GeoJSONContentType},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/category/{category}.geojson", tag: tagExport, access: optionalSession,
+		summary: "Export the spots of a category as GeoJSON",
+		query:   exportQuery[1:],
+		responses: []response{
+			{status: http.StatusOK, body: featureCollection, contentType: export.GeoJSONContentType},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/export.gpx", tag: tagExport, access: optionalSession,
+		summary: "Download spots as GPX waypoints",
+		query:   exportQuery,
+		responses: []response{
+			{status: http.StatusOK, body: text, contentType: export.GPXContentType},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/spots/export.kml", tag: tagExport, access: optionalSession,
+		summary: "Download spots as KML, a folder per category",
+		query:   exportQuery,
+		responses: []response{
+			{status: http.StatusOK, body: text, contentType: export.KMLContentType},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Submissions
+	{
+		method: http.MethodPost, path: "/submissions/", tag: tagSubmissions, access: session,
+		summary: "Propose a spot",
+		body:    handler.SpotRequest{},
+		responses: []response{
+			{status: http.StatusCreated, body: submission},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/submissions/{id}/images", tag: tagSubmissions, access: session,
+		summary: "Add a photo to an own pending submission",
+		upload:  "image",
+		responses: []response{
+			{status: http.StatusCreated, body: dto.SubmissionImageDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusRequestEntityTooLarge},
+	},
+	{
+		method: http.MethodGet, path: "/me/submissions", tag: tagSubmissions, access: session,
+		summary: "List the submissions of the user",
+		responses: []response{
+			{status: http.StatusOK, body: []dto.SubmissionDTO{}},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/admin/submissions/", tag: tagSubmissions, access: admin,
+		summary: "List submissions by status",
+		query: []*Parameter{
+			queryParam("status", "Defaults to pending", &Schema{Type: "string", Enum: enumValues(db.AllSubmissionStatusValues())}),
+		},
+		responses: []response{
+			{status: http.StatusOK, body: []dto.SubmissionDTO{}},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/admin/submissions/{id}", tag: tagSubmissions, access: admin,
+		summary: "Get a submission",
+		responses: []response{
+			{status: http.StatusOK, body: submission},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		method: http.MethodPut, path: "/admin/submissions/{id}", tag: tagSubmissions, access: admin,
+		summary: "Correct a pending submission",
+		body:    handler.SpotRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: submission},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodPost, path: "/admin/submissions/{id}/approve", tag: tagSubmissions, access: admin,
+		summary: "Approve a submission, creating its spot",
+		responses: []response{
+			{status: http.StatusOK, body: submission},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodPost, path: "/admin/submissions/{id}/reject", tag: tagSubmissions, access: admin,
+		summary: "Reject a submission",
+		body:    handler.RejectSubmissionRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: submission},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+
+	// Categories
+	{
+		method: http.MethodGet, path: "/categories", tag: tagCategories, access: public,
+		summary: "List categories in display order",
+		responses: []response{
+			{status: http.StatusOK, body: []dto.CategoryDTO{}},
+		},
+	},
+	{
+		method: http.MethodPost, path: "/admin/categories/", tag: tagCategories, access: admin,
+		summary: "Create a category",
+		body:    handler.CategoryRequest{},
+		responses: []response{
+			{status: http.StatusCreated, body: dto.CategoryDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusConflict},
+	},
+	{
+		method: http.MethodPut, path: "/admin/categories/order", tag: tagCategories, access: admin,
+		summary: "Reorder all categories",
+		body:    handler.ReorderCategoriesRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: []dto.CategoryDTO{}},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodPut, path: "/admin/categories/{slug}", tag: tagCategories, access: admin,
+		summary: "Update a category",
+		body:    handler.CategoryRequest{},
+		responses: []response{
+			{status: http.StatusOK, body: dto.CategoryDTO{}},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+
+	// Admin
+	{
+		method: http.MethodGet, path: "/admin/schema", tag: tagAdmin, access: admin,
+		summary: "Get the applied migration version",
+		responses: []response{
+			{status: http.StatusOK, body: migrate.Status{}},
+		},
+	},
+
+	// Health and docs
+	{
+		method: http.MethodGet, path: "/healthz", tag: tagHealth, access: public,
+		summary: "Liveness probe",
+		responses: []response{
+			{status: http.StatusOK, body: health},
+		},
+		noServerError: true,
+	},
+	{
+		method: http.MethodGet, path: "/readyz", tag: tagHealth, access: public,
+		summary:     "Readiness probe",
+		description: "Checks the database, the migrations and the session store.",
+		responses: []response{
+			{status: http.StatusOK, body: health},
+			{status: http.StatusServiceUnavailable, description: "A check failed or the server is draining", body: health},
+		},
+		noServerError: true,
+	},
+	{
+		method: http.MethodGet, path: "/public/health", tag: tagHealth, access: public,
+		summary: "Readiness probe, kept for the frontend",
+		responses: []response{
+			{status: http.StatusOK, body: health},
+			{status: http.StatusServiceUnavailable, description: "A check failed or the server is draining", body: health},
+		},
+		noServerError: true,
+	},
+	{
+		method: http.MethodGet, path: "/openapi.json", tag: tagHealth, access: public,
+		summary: "This document",
+		responses: []response{
+			{status: http.StatusOK, body: &Schema{Type: "object"}},
+		},
+		noServerError: true,
+	},
+	{
+		method: http.MethodGet, path: "/docs", tag: tagHealth, access: public,
+		summary: "API documentation browser",
+		responses: []response{
+			{status: http.StatusOK, body: text, contentType: "text/html"},
+		},
+		noServerError: true,
+	},
+}
@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/migrate"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// enums lists the values of the sqlc enum types, they are plain strings to reflection
+var enums = map[reflect.Type][]string{
+	reflect.TypeFor[db.EquipmentCondition](): enumValues(db.AllEquipmentConditionValues()),
+	reflect.TypeFor[db.EquipmentType]():      enumValues(db.AllEquipmentTypeValues()),
+	reflect.TypeFor[db.SubmissionStatus]():   enumValues(db.AllSubmissionStatusValues()),
+	reflect.TypeFor[db.UserRole]():           enumValues(db.AllUserRoleValues()),
+}
+
+// knownTypes marshal themselves, their Go fields say nothing about the JSON
+var knownTypes = map[reflect.Type]*Schema{
+	reflect.TypeFor[time.Time]():          {Type: "string", Format: "date-time"},
+	reflect.TypeFor[json.RawMessage]():    {Description: "Any JSON value"},
+	reflect.TypeFor[pgtype.Timestamp]():   {Type: "string", Format: "date-time", Nullable: true},
+	reflect.TypeFor[pgtype.Timestamptz](): {Type: "string", Format: "date-time", Nullable: true},
+	reflect.TypeFor[pgtype.Date]():        {Type: "string", Format: "date", Nullable: true},
+	reflect.TypeFor[pgtype.Text]():        {Type: "string", Nullable: true},
+}
+
+// schemaNames renames types whose Go name says too little on its own
+var schemaNames = map[reflect.Type]string{
+	reflect.TypeFor[migrate.Status](): "SchemaStatus",
+}
+
+func enumValues[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// schemaBuilder turns Go types into schemas the way encoding/json would encode them,
+// named structs become components
+type schemaBuilder struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{components: map[string]*Schema{}, types: map[string]reflect.Type{}}
+}
+
+// of returns the schema of the type of v
+func (b *schemaBuilder) of(v any) *Schema {
+	return b.schema(reflect.TypeOf(v))
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) *Schema {
+	if known, ok := knownTypes[t]; ok {
+		copied := *known
+		return &copied
+	}
+	if values, ok := enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := b.schema(t.Elem())
+		if s.Ref != "" {
+			// Siblings of $ref are ignored in OpenAPI 3.0
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		return b.component(t)
+	}
+	panic(fmt.Sprintf("openapi: no schema for %s", t))
+}
+
+func (b *schemaBuilder) component(t reflect.Type) *Schema {
+	name, ok := schemaNames[t]
+	if !ok {
+		name = t.Name()
+	}
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+
+	if existing, ok := b.types[name]; ok {
+		if existing != t {
+			panic(fmt.Sprintf("openapi: %s and %s are both called %s", existing, t, name))
+		}
+		return ref
+	}
+	// Registered before the fields so recursive types end in a $ref
+	b.types[name] = t
+	b.components[name] = b.object(t)
+	return ref
+}
+
+// object lists the fields encoding/json writes, embedded structs are flattened. A
+// field without omitempty is always present, so it is required.
+func (b *schemaBuilder) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	b.addFields(s, t)
+	return s
+}
+
+func (b *schemaBuilder) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			b.addFields(s, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = b.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
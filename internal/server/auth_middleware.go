@@ -1,8 +1,10 @@
 package server
 
 import (
+	"PilaiteProject/internal/auth"
+	"PilaiteProject/internal/i18n"
 	"PilaiteProject/internal/interfaces"
-	"context"
+	"PilaiteProject/internal/logging"
 	"encoding/json"
 	"net/http"
 )
@@ -20,12 +22,11 @@ func NewAuthMiddleware(sessionManager interfaces.SessionProvider) *AuthMiddlewar
 
 // ====== CONTEXT KEYS ======
 
-type contextKey string
-
+// Keys live in the auth package so handlers can read them without importing server
 const (
-	userIDKey    contextKey = "userID"
-	userRoleKey  contextKey = "userRole"
-	userEmailKey contextKey = "userEmail"
+	userIDKey    = auth.UserIDKey
+	userRoleKey  = auth.UserRoleKey
+	userEmailKey = auth.UserEmailKey
 )
 
 // ====== MIDDLEWARE FUNCTIONS ======
@@ -35,24 +36,20 @@ const (
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if user is authenticated
-		//fmt.Printf("REQUIRE AUTH DEBUG: Checking auth, session token: %v\n", m.sessionManager.Token(r.Context()))
-
 		userID := m.sessionManager.GetInt(r.Context(), "userID")
-		//fmt.Printf("REQUIRE AUTH DEBUG: UserID from session: %d\n", userID)
 		if userID == 0 {
-			respondWithError(w, http.StatusUnauthorized, "Authentication required")
+			logging.FromContext(r.Context()).Debug("no user in session")
+			respondWithError(w, r, http.StatusUnauthorized, "Authentication required")
 			return
 		}
+		logging.SetUserID(r.Context(), userID)
 
 		// Get additional user info from session
 		role := m.sessionManager.GetString(r.Context(), "role")
 		email := m.sessionManager.GetString(r.Context(), "email")
 
 		// Add user info to request context for use in handlers
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, userIDKey, userID)
-		ctx = context.WithValue(ctx, userRoleKey, role)
-		ctx = context.WithValue(ctx, userEmailKey, email)
+		ctx := auth.WithUser(r.Context(), userID, role, email)
 
 		// Continue to next handler
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -66,14 +63,15 @@ func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
 		// Check if user is authenticated
 		userID := m.sessionManager.GetInt(r.Context(), "userID")
 		if userID == 0 {
-			respondWithError(w, http.StatusUnauthorized, "Authentication required")
+			respondWithError(w, r, http.StatusUnauthorized, "Authentication required")
 			return
 		}
+		logging.SetUserID(r.Context(), userID)
 
 		// Check if user is admin
 		role := m.sessionManager.GetString(r.Context(), "role")
 		if role != "admin" {
-			respondWithError(w, http.StatusForbidden, "Admin access required")
+			respondWithError(w, r, http.StatusForbidden, "Admin access required")
 			return
 		}
 
@@ -81,16 +79,31 @@ func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
 		email := m.sessionManager.GetString(r.Context(), "email")
 
 		// Add user info to request context
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, userIDKey, userID)
-		ctx = context.WithValue(ctx, userRoleKey, role)
-		ctx = context.WithValue(ctx, userEmailKey, email)
+		ctx := auth.WithUser(r.Context(), userID, role, email)
 
 		// Continue to next handler
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// OptionalAuth adds user info to the context when a session exists but never rejects the request
+// Use this for public routes whose response depends on the session, e.g. secret spot visibility
+func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := m.sessionManager.GetInt(r.Context(), "userID")
+		if userID == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		logging.SetUserID(r.Context(), userID)
+
+		role := m.sessionManager.GetString(r.Context(), "role")
+		email := m.sessionManager.GetString(r.Context(), "email")
+
+		next.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), userID, role, email)))
+	})
+}
+
 // RequireGuest ensures user is NOT logged in
 // Use this for routes like login/register pages that shouldn't be accessible when authenticated
 func (m *AuthMiddleware) RequireGuest(next http.Handler) http.Handler {
@@ -98,7 +111,7 @@ func (m *AuthMiddleware) RequireGuest(next http.Handler) http.Handler {
 		// Check if user is authenticated
 		userID := m.sessionManager.GetInt(r.Context(), "userID")
 		if userID != 0 {
-			respondWithError(w, http.StatusForbidden, "Already authenticated")
+			respondWithError(w, r, http.StatusForbidden, "Already authenticated")
 			return
 		}
 
@@ -107,8 +120,8 @@ func (m *AuthMiddleware) RequireGuest(next http.Handler) http.Handler {
 	})
 }
 
-func respondWithError(w http.ResponseWriter, code int, message string) {
-	response, _ := json.Marshal(map[string]string{"error": message})
+func respondWithError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	response, _ := json.Marshal(map[string]string{"error": i18n.T(r.Context(), message)})
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	w.Write(response)
@@ -122,7 +135,7 @@ func respondWithError(w http.ResponseWriter, code int, message string) {
 //			// Check if user is authenticated
 //			userID := m.sessionManager.GetInt(r.Context(), "userID")
 //			if userID == 0 {
-//				respondWithError(w, http.StatusUnauthorized, "Authentication required")
+//				respondWithError(w, r, http.StatusUnauthorized, "Authentication required")
 //				return
 //			}
 //
@@ -140,7 +153,7 @@ func respondWithError(w http.ResponseWriter, code int, message string) {
 //			}
 //
 //			if !allowed {
-//				respondWithError(w, http.StatusForbidden, "Insufficient permissions")
+//				respondWithError(w, r, http.StatusForbidden, "Insufficient permissions")
 //				return
 //			}
 //
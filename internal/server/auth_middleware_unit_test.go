@@ -243,3 +243,69 @@ func TestRequireGuest_Forbidden(t *testing.T) {
 		t.Fatalf("expected 403 Forbidden, got %d", rr.Code)
 	}
 }
+
+func TestOptionalAuth_Anonymous(t *testing.T) {
+	mock := &mocks.MockSessionManager{
+		GetIntFunc: func(ctx context.Context, key string) int {
+			return 0
+		},
+		GetStringFunc: func(ctx context.Context, key string) string {
+			return ""
+		},
+	}
+
+	middleware := NewAuthMiddleware(mock)
+
+	called := false
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Context().Value(userIDKey) != nil {
+			t.Fatalf("expected no userID in context")
+		}
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	middleware.OptionalAuth(nextHandler).ServeHTTP(rr, req)
+
+	if !called {
+		t.Fatal("next handler was NOT called")
+	}
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestOptionalAuth_LoggedIn(t *testing.T) {
+	mock := &mocks.MockSessionManager{
+		GetIntFunc: func(ctx context.Context, key string) int {
+			return 42
+		},
+		GetStringFunc: func(ctx context.Context, key string) string {
+			if key == "role" {
+				return "user"
+			}
+			return ""
+		},
+	}
+
+	middleware := NewAuthMiddleware(mock)
+
+	called := false
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Context().Value(userIDKey).(int) != 42 {
+			t.Fatalf("expected userID 42")
+		}
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+
+	middleware.OptionalAuth(nextHandler).ServeHTTP(rr, req)
+
+	if !called {
+		t.Fatal("next handler was NOT called")
+	}
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"PilaiteProject/internal/logging"
+	"log/slog"
 	"time"
 
 	"github.com/alexedwards/scs/v2"
@@ -9,22 +11,26 @@ import (
 	"github.com/go-chi/cors"
 )
 
-func applyGlobalMiddleware(router *chi.Mux, sessionManager *scs.SessionManager) {
-	// Request logging and recovery
+func applyGlobalMiddleware(router *chi.Mux, sessionManager *scs.SessionManager, languageMiddleware *LanguageMiddleware, corsOrigins []string, httpMetrics *httpMetrics) {
+	// Request logging and recovery, the logger comes first so it also sees panics
 	router.Use(middleware.RequestID)
+	router.Use(middleware.RequestLogger(&logging.RequestFormatter{Logger: slog.Default()}))
+	router.Use(httpMetrics.middleware)
 	router.Use(middleware.Recoverer)
-	router.Use(middleware.Logger)
 	router.Use(middleware.Timeout(30 * time.Second))
 
 	// Session management (must come before auth middleware)
 	router.Use(sessionManager.LoadAndSave)
 
+	// Reads the language preference from the session, so it comes after LoadAndSave
+	router.Use(languageMiddleware.Negotiate)
+
 	// CORS configuration
 	router.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedOrigins:   corsOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Link", "Content-Language"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/migrate"
+	"context"
+	"fmt"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// readinessProbeToken is looked up in the session store, it never exists
+const readinessProbeToken = "readiness-probe"
+
+func readinessChecks(pool *pgxpool.Pool, migrator *migrate.Migrator, store scs.Store) []handler.HealthCheck {
+	return []handler.HealthCheck{
+		{Name: "database", Check: pool.Ping},
+		{Name: "migrations", Check: func(ctx context.Context) error {
+			return checkMigrations(ctx, migrator)
+		}},
+		{Name: "sessions", Check: func(ctx context.Context) error {
+			// The store has no context, the handler times the check out instead
+			_, _, err := store.Find(readinessProbeToken)
+			return err
+		}},
+	}
+}
+
+// schemaStatuser is the part of the migrator the readiness check uses
+type schemaStatuser interface {
+	Status(ctx context.Context) (migrate.Status, error)
+}
+
+// checkMigrations fails while the schema is older than the binary or a migration
+// failed halfway
+func checkMigrations(ctx context.Context, migrator schemaStatuser) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("schema is dirty at version %d", status.Version)
+	}
+	if status.Pending > 0 {
+		return fmt.Errorf("%d pending migrations, schema is at version %d of %d", status.Pending, status.Version, status.Latest)
+	}
+	return nil
+}
+
+func setupHealthRoutes(router *chi.Mux, healthHandler *handler.HealthHandler) {
+	router.Get("/healthz", healthHandler.Liveness)
+	router.Get("/readyz", healthHandler.Readiness)
+}
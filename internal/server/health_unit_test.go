@@ -0,0 +1,135 @@
+package server
+
+import (
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/migrate"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func probe(t *testing.T, healthHandler *handler.HealthHandler, path string) (int, dto.HealthDTO) {
+	t.Helper()
+	router := chi.NewRouter()
+	setupHealthRoutes(router, healthHandler)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+
+	var response dto.HealthDTO
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	return rr.Code, response
+}
+
+func passing(context.Context) error { return nil }
+
+func TestReadiness(t *testing.T) {
+	var draining atomic.Bool
+	down := errors.New("connection refused")
+	healthHandler := handler.NewHealthHandler(draining.Load,
+		handler.HealthCheck{Name: "database", Check: passing},
+		handler.HealthCheck{Name: "sessions", Check: passing},
+	)
+
+	code, response := probe(t, healthHandler, "/readyz")
+	if code != http.StatusOK || response.Status != "ready" || len(response.Checks) != 2 {
+		t.Fatalf("expected ready, got %d %+v", code, response)
+	}
+	if response.Checks["database"].Status != "ok" {
+		t.Fatalf("unexpected database check: %+v", response.Checks["database"])
+	}
+
+	failing := handler.NewHealthHandler(draining.Load,
+		handler.HealthCheck{Name: "database", Check: func(context.Context) error { return down }},
+		handler.HealthCheck{Name: "sessions", Check: passing},
+	)
+	code, response = probe(t, failing, "/readyz")
+	if code != http.StatusServiceUnavailable || response.Status != "unavailable" {
+		t.Fatalf("expected unavailable, got %d %+v", code, response)
+	}
+	if check := response.Checks["database"]; check.Status != "fail" || check.Error != down.Error() {
+		t.Fatalf("unexpected database check: %+v", check)
+	}
+	if response.Checks["sessions"].Status != "ok" {
+		t.Fatalf("a failing check should not affect the others: %+v", response.Checks)
+	}
+
+	draining.Store(true)
+	code, response = probe(t, healthHandler, "/readyz")
+	if code != http.StatusServiceUnavailable || response.Status != "draining" {
+		t.Fatalf("expected draining, got %d %+v", code, response)
+	}
+
+	// Liveness doesn't care about draining or dependencies
+	code, response = probe(t, failing, "/healthz")
+	if code != http.StatusOK || response.Status != "ok" || response.Checks != nil {
+		t.Fatalf("expected alive, got %d %+v", code, response)
+	}
+}
+
+func TestReadiness_CheckIgnoringContextTimesOut(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for the check timeout")
+	}
+	block := make(chan struct{})
+	defer close(block)
+
+	healthHandler := handler.NewHealthHandler(func() bool { return false },
+		handler.HealthCheck{Name: "sessions", Check: func(context.Context) error {
+			<-block
+			return nil
+		}},
+	)
+
+	code, response := probe(t, healthHandler, "/readyz")
+	if code != http.StatusServiceUnavailable || response.Checks["sessions"].Error != "timed out" {
+		t.Fatalf("expected a timeout, got %d %+v", code, response)
+	}
+}
+
+type fakeSchema struct {
+	status migrate.Status
+	err    error
+}
+
+func (f fakeSchema) Status(ctx context.Context) (migrate.Status, error) {
+	return f.status, f.err
+}
+
+func TestCheckMigrations(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema fakeSchema
+		want   string
+	}{
+		{name: "up to date", schema: fakeSchema{status: migrate.Status{Version: 9, Latest: 9}}},
+		{name: "pending", schema: fakeSchema{status: migrate.Status{Version: 7, Latest: 9, Pending: 2}}, want: "2 pending migrations"},
+		{name: "dirty", schema: fakeSchema{status: migrate.Status{Version: 8, Latest: 9, Dirty: true}}, want: "dirty at version 8"},
+		{name: "unreachable", schema: fakeSchema{err: errors.New("connection refused")}, want: "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMigrations(context.Background(), tt.schema)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
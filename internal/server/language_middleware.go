@@ -0,0 +1,37 @@
+package server
+
+import (
+	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/interfaces"
+	"net/http"
+)
+
+// LanguageMiddleware decides the language of the response: the user's saved preference
+// first, then the Accept-Language header, then i18n.DefaultLanguage
+type LanguageMiddleware struct {
+	bundle         *i18n.Bundle
+	sessionManager interfaces.SessionProvider
+}
+
+func NewLanguageMiddleware(bundle *i18n.Bundle, sessionManager interfaces.SessionProvider) *LanguageMiddleware {
+	return &LanguageMiddleware{
+		bundle:         bundle,
+		sessionManager: sessionManager,
+	}
+}
+
+func (m *LanguageMiddleware) Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := m.sessionManager.GetString(r.Context(), handler.LanguageSessionKey)
+		if !m.bundle.Supports(language) {
+			language = m.bundle.Negotiate(r.Header.Get("Accept-Language"))
+		}
+
+		// Caches must not hand a Lithuanian response to an English client
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", language)
+
+		next.ServeHTTP(w, r.WithContext(i18n.WithLanguage(r.Context(), m.bundle, language)))
+	})
+}
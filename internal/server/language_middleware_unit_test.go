@@ -0,0 +1,87 @@
+package server
+
+import (
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func negotiatedLanguage(t *testing.T, sessionLanguage, acceptLanguage string) (string, *httptest.ResponseRecorder) {
+	t.Helper()
+	bundle, err := i18n.NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mockSession := &mocks.MockSessionManager{
+		GetStringFunc: func(ctx context.Context, key string) string {
+			if key == "language" {
+				return sessionLanguage
+			}
+			return ""
+		},
+	}
+
+	var language string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language = i18n.Language(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", acceptLanguage)
+	rr := httptest.NewRecorder()
+
+	NewLanguageMiddleware(bundle, mockSession).Negotiate(next).ServeHTTP(rr, req)
+	return language, rr
+}
+
+func TestLanguageMiddleware_AcceptLanguage(t *testing.T) {
+	language, rr := negotiatedLanguage(t, "", "en-US,en;q=0.9")
+
+	if language != "en" {
+		t.Fatalf("expected en, got %s", language)
+	}
+	if rr.Header().Get("Content-Language") != "en" || rr.Header().Get("Vary") != "Accept-Language" {
+		t.Fatalf("unexpected headers: %v", rr.Header())
+	}
+}
+
+func TestLanguageMiddleware_SessionPreferenceWins(t *testing.T) {
+	language, _ := negotiatedLanguage(t, "lt", "en")
+
+	if language != "lt" {
+		t.Fatalf("expected the saved lt preference, got %s", language)
+	}
+}
+
+func TestLanguageMiddleware_DefaultLanguage(t *testing.T) {
+	language, _ := negotiatedLanguage(t, "xx", "fr-FR")
+
+	if language != i18n.DefaultLanguage {
+		t.Fatalf("expected %s, got %s", i18n.DefaultLanguage, language)
+	}
+}
+
+func TestRequireAuth_TranslatesError(t *testing.T) {
+	bundle, err := i18n.NewBundle()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mockSession := &mocks.MockSessionManager{
+		GetIntFunc: func(ctx context.Context, key string) int {
+			return 0
+		},
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req = req.WithContext(i18n.WithLanguage(req.Context(), bundle, "lt"))
+	rr := httptest.NewRecorder()
+
+	NewAuthMiddleware(mockSession).RequireAuth(http.NotFoundHandler()).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized || rr.Body.String() != `{"error":"Būtina prisijungti"}` {
+		t.Fatalf("unexpected response: %d %s", rr.Code, rr.Body.String())
+	}
+}
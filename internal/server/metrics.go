@@ -0,0 +1,128 @@
+package server
+
+import (
+	"PilaiteProject/internal/config"
+	"PilaiteProject/internal/metrics"
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sessionCountTimeout keeps a slow database from stalling the whole scrape
+const sessionCountTimeout = 2 * time.Second
+
+// httpMetrics counts requests by chi route pattern rather than path, so spot IDs
+// don't each get their own series
+type httpMetrics struct {
+	requests  *metrics.CounterVec
+	durations *metrics.HistogramVec
+}
+
+func newHTTPMetrics(registry *metrics.Registry) *httpMetrics {
+	return &httpMetrics{
+		requests: registry.NewCounterVec("http_requests_total",
+			"HTTP requests by method, chi route pattern and status.", "method", "route", "status"),
+		durations: registry.NewHistogramVec("http_request_duration_seconds",
+			"HTTP request latency by method, chi route pattern and status.", metrics.DefaultBuckets, "method", "route", "status"),
+	}
+}
+
+func (m *httpMetrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+		defer func() {
+			// Nothing written means net/http answers 200
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			labels := []string{r.Method, route, strconv.Itoa(status)}
+			m.requests.Inc(labels...)
+			m.durations.Observe(time.Since(start).Seconds(), labels...)
+		}()
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// registerPoolMetrics exports pgxpool.Stat, read on every scrape
+func registerPoolMetrics(registry *metrics.Registry, pool *pgxpool.Pool) {
+	gauge := func(name, help string, value func(*pgxpool.Stat) float64) {
+		registry.NewGaugeFunc(name, help, func(context.Context) (float64, error) {
+			return value(pool.Stat()), nil
+		})
+	}
+	counter := func(name, help string, value func(*pgxpool.Stat) float64) {
+		registry.NewCounterFunc(name, help, func(context.Context) (float64, error) {
+			return value(pool.Stat()), nil
+		})
+	}
+
+	gauge("pgxpool_acquired_conns", "Connections currently in use.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })
+	gauge("pgxpool_idle_conns", "Connections open but not in use.",
+		func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })
+	gauge("pgxpool_total_conns", "Connections open, including those being established.",
+		func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })
+	gauge("pgxpool_max_conns", "Maximum size of the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })
+	counter("pgxpool_acquires_total", "Connections acquired from the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) })
+	counter("pgxpool_empty_acquires_total", "Acquires that had to wait because the pool had no idle connection.",
+		func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })
+	counter("pgxpool_empty_acquire_wait_seconds_total", "Time spent waiting in acquires that found the pool empty.",
+		func(s *pgxpool.Stat) float64 { return s.EmptyAcquireWaitTime().Seconds() })
+	counter("pgxpool_canceled_acquires_total", "Acquires canceled by their context.",
+		func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) })
+}
+
+// sessionCounter is the query behind the sessions_active gauge
+type sessionCounter interface {
+	CountActiveSessions(ctx context.Context) (int64, error)
+}
+
+func registerSessionMetrics(registry *metrics.Registry, sessions sessionCounter) {
+	registry.NewGaugeFunc("sessions_active", "Sessions that have not expired.", func(ctx context.Context) (float64, error) {
+		ctx, cancel := context.WithTimeout(ctx, sessionCountTimeout)
+		defer cancel()
+		count, err := sessions.CountActiveSessions(ctx)
+		return float64(count), err
+	})
+}
+
+// setupMetrics exposes the registry as configured: on its own listener, which is
+// returned, on the main router behind a bearer token, or not at all
+func setupMetrics(router *chi.Mux, registry *metrics.Registry, metricsConfig config.MetricsConfig) *http.Server {
+	switch {
+	case metricsConfig.Addr != "":
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", registry.Handler())
+		return &http.Server{Addr: metricsConfig.Addr, Handler: mux}
+	case metricsConfig.Token != "":
+		router.Get("/metrics", requireBearerToken(metricsConfig.Token, registry.Handler()).ServeHTTP)
+	}
+	return nil
+}
+
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			respondWithError(w, r, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
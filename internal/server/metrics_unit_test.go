@@ -0,0 +1,132 @@
+package server
+
+import (
+	"PilaiteProject/internal/config"
+	"PilaiteProject/internal/metrics"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func scrapeRegistry(t *testing.T, registry *metrics.Registry) string {
+	t.Helper()
+	var out strings.Builder
+	if err := registry.Write(context.Background(), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out.String()
+}
+
+func TestHTTPMetrics_LabelsByRoutePattern(t *testing.T) {
+	registry := metrics.NewRegistry()
+	router := chi.NewRouter()
+	router.Use(newHTTPMetrics(registry).middleware)
+	router.Get("/spots/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if chi.URLParam(r, "id") == "0" {
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+
+	for _, path := range []string{"/spots/1", "/spots/2", "/spots/0", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	out := scrapeRegistry(t, registry)
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/spots/{id}",status="200"} 2`,
+		`http_requests_total{method="GET",route="/spots/{id}",status="404"} 1`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/spots/{id}",status="200"} 2`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in:\n%s", want, out)
+		}
+	}
+}
+
+type fakeSessionCounter struct {
+	count int64
+	err   error
+}
+
+func (f fakeSessionCounter) CountActiveSessions(ctx context.Context) (int64, error) {
+	return f.count, f.err
+}
+
+func TestSessionMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registerSessionMetrics(registry, fakeSessionCounter{count: 3})
+	if out := scrapeRegistry(t, registry); !strings.Contains(out, "sessions_active 3\n") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+
+	registry = metrics.NewRegistry()
+	registerSessionMetrics(registry, fakeSessionCounter{err: errors.New("database is down")})
+	if out := scrapeRegistry(t, registry); strings.Contains(out, "sessions_active") {
+		t.Fatalf("expected the failed gauge to be left out:\n%s", out)
+	}
+}
+
+func TestSetupMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.NewCounterVec("up_total", "Up.").Inc()
+
+	t.Run("off by default", func(t *testing.T) {
+		router := chi.NewRouter()
+		if setupMetrics(router, registry, config.MetricsConfig{}) != nil {
+			t.Fatalf("expected no separate listener")
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("token on the main router", func(t *testing.T) {
+		router := chi.NewRouter()
+		setupMetrics(router, registry, config.MetricsConfig{Token: "s3cret"})
+
+		for header, want := range map[string]int{
+			"":              http.StatusUnauthorized,
+			"Bearer wrong":  http.StatusUnauthorized,
+			"s3cret":        http.StatusUnauthorized,
+			"Bearer s3cret": http.StatusOK,
+		} {
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			if rr.Code != want {
+				t.Errorf("Authorization %q: expected %d, got %d", header, want, rr.Code)
+			}
+		}
+	})
+
+	t.Run("separate listener", func(t *testing.T) {
+		router := chi.NewRouter()
+		metricsServer := setupMetrics(router, registry, config.MetricsConfig{Addr: "127.0.0.1:9090", Token: "ignored"})
+		if metricsServer == nil || metricsServer.Addr != "127.0.0.1:9090" {
+			t.Fatalf("expected a metrics listener, got %+v", metricsServer)
+		}
+
+		rr := httptest.NewRecorder()
+		metricsServer.Handler.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "up_total 1") {
+			t.Fatalf("unexpected response %d:\n%s", rr.Code, rr.Body.String())
+		}
+
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("expected metrics to stay off the main router, got %d", rr.Code)
+		}
+	})
+}
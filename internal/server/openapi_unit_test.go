@@ -0,0 +1,96 @@
+package server
+
+import (
+	"PilaiteProject/db/migration"
+	"PilaiteProject/internal/dbConfig"
+	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/migrate"
+	"PilaiteProject/internal/openapi"
+	"PilaiteProject/internal/storage"
+	"PilaiteProject/internal/wrapper"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+)
+
+// newAPIRouter registers the routes of the server, the dependencies are never called
+func newAPIRouter(t *testing.T) *chi.Mux {
+	t.Helper()
+	bundle, err := i18n.NewBundle()
+	if err != nil {
+		t.Fatalf("failed to load translations: %v", err)
+	}
+	uploadStorage, err := storage.NewLocalStorage(t.TempDir(), uploadURLPrefix)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	migrator, err := migrate.New(nil, migration.FS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	router := chi.NewRouter()
+	conn := &dbConfig.Connection{Queries: wrapper.NewAppQueries(nil)}
+	healthHandler := handler.NewHealthHandler(func() bool { return false })
+	setupRoutes(router, conn, scs.New(), bundle, uploadStorage, migrator, healthHandler, nil, false)
+	return router
+}
+
+func TestOpenAPI_DescribesEveryRoute(t *testing.T) {
+	router := newAPIRouter(t)
+	paths := openapi.Build().Paths
+
+	registered := map[string]bool{}
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + route
+		registered[key] = true
+		if _, ok := paths[route][strings.ToLower(method)]; !ok {
+			t.Errorf("%s is registered but missing from the OpenAPI document", key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	for path, item := range paths {
+		for method := range item {
+			if key := strings.ToUpper(method) + " " + path; !registered[key] {
+				t.Errorf("%s is documented but not registered", key)
+			}
+		}
+	}
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	router := newAPIRouter(t)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/openapi.json", nil))
+	if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("expected JSON, got %d %q", rr.Code, rr.Header().Get("Content-Type"))
+	}
+	var doc openapi.Document
+	if err := json.NewDecoder(rr.Body).Decode(&doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := doc.Paths["/spots/"]["get"]; !ok {
+		t.Fatalf("expected GET /spots/ in the served document")
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/docs", nil))
+	if rr.Code != http.StatusOK || !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("expected HTML, got %d %q", rr.Code, rr.Header().Get("Content-Type"))
+	}
+	// Offline means nothing is loaded from elsewhere
+	if body := rr.Body.String(); strings.Contains(body, "http://") || strings.Contains(body, "https://") {
+		t.Fatalf("docs page loads external resources")
+	}
+}
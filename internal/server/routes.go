@@ -3,39 +3,73 @@ package server
 import (
 	"PilaiteProject/internal/dbConfig"
 	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/interfaces"
+	"PilaiteProject/internal/metrics"
+	"PilaiteProject/internal/migrate"
+	"PilaiteProject/internal/openapi"
 	"PilaiteProject/internal/service"
-	"net/http"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
 )
 
-func setupRoutes(router *chi.Mux, conn *dbConfig.Connection, sessionManager *scs.SessionManager) {
+func setupRoutes(router *chi.Mux, conn *dbConfig.Connection, sessionManager *scs.SessionManager, bundle *i18n.Bundle, uploadStorage interfaces.FileStorage, migrator *migrate.Migrator, healthHandler *handler.HealthHandler, logins *metrics.CounterVec, keepImageMetadata bool) {
 
 	userService := service.NewUserService(conn.Queries)
 
 	spotService := service.NewSpotService(conn.Queries)
 
-	spotHandler := handler.NewSpotHandler(spotService)
+	imageService := service.NewImageService(conn.Queries, uploadStorage, keepImageMetadata)
 
-	authHandler := handler.NewAuthHandler(userService, sessionManager)
+	spotHandler := handler.NewSpotHandler(spotService, imageService)
+
+	imageHandler := handler.NewImageHandler(imageService)
+
+	reviewService := service.NewReviewService(conn.Queries)
+
+	reviewHandler := handler.NewReviewHandler(reviewService)
+
+	submissionService := service.NewSubmissionService(conn.Queries, imageService)
+
+	submissionHandler := handler.NewSubmissionHandler(submissionService)
+
+	categoryService := service.NewCategoryService(conn.Queries)
+
+	categoryHandler := handler.NewCategoryHandler(categoryService)
+
+	authHandler := handler.NewAuthHandler(userService, sessionManager, logins)
+
+	languageHandler := handler.NewLanguageHandler(bundle, sessionManager)
+
+	schemaHandler := handler.NewSchemaHandler(migrator)
 
 	authMiddleware := NewAuthMiddleware(sessionManager)
 
-	setupSpotRoutes(router, spotHandler, authMiddleware)
+	setupSpotRoutes(router, spotHandler, imageHandler, reviewHandler, authMiddleware)
+	setupMeRoutes(router, spotHandler, submissionHandler, languageHandler, authMiddleware)
+	setupSubmissionRoutes(router, submissionHandler, authMiddleware)
+	setupCategoryRoutes(router, categoryHandler, authMiddleware)
+	setupSchemaRoutes(router, schemaHandler, authMiddleware)
 
-	setupPublicRoutes(router)
+	setupHealthRoutes(router, healthHandler)
+	setupPublicRoutes(router, healthHandler)
 	setupAuthRoutes(router, authHandler, authMiddleware)
+	setupDocsRoutes(router)
+
+}
 
+// setupDocsRoutes serves the API description, openapi_unit_test.go keeps it in step
+// with the routes above
+func setupDocsRoutes(router *chi.Mux) {
+	router.Get("/openapi.json", openapi.SpecHandler())
+	router.Get("/docs", openapi.DocsHandler())
 }
 
-func setupPublicRoutes(router *chi.Mux) {
+func setupPublicRoutes(router *chi.Mux, healthHandler *handler.HealthHandler) {
 	router.Route("/public", func(router chi.Router) {
-		router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(`{"status":"healthy"}`))
-		})
+		// Kept for the frontend, same answer as /readyz
+		router.Get("/health", healthHandler.Readiness)
 	})
 }
 
@@ -54,17 +88,40 @@ func setupAuthRoutes(router *chi.Mux, authHandler *handler.AuthHandler, authMidd
 	})
 }
 
-func setupSpotRoutes(router *chi.Mux, spotHandler *handler.SpotHandler, authMiddleware *AuthMiddleware) {
+func setupSpotRoutes(router *chi.Mux, spotHandler *handler.SpotHandler, imageHandler *handler.ImageHandler, reviewHandler *handler.ReviewHandler, authMiddleware *AuthMiddleware) {
+	// Map exports stream every visible spot, secret ones only with a session
+	router.With(authMiddleware.OptionalAuth).Get("/spots.geojson", spotHandler.ExportGeoJSON)
+
 	router.Route("/spots", func(r chi.Router) {
-		//r.Group(func(router chi.Router) {
-		//	//router.Use(authMiddleware.RequireAdmin)
-		//	r.Post("/", spotHandler.CreateSpot)
-		//})
+		// Admin only spot management
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.RequireAdmin)
+			r.Post("/", spotHandler.InsertSpot)
+			r.Post("/import", spotHandler.ImportSpots)
+			r.Put("/{id}", spotHandler.UpdateSpot)
+			r.Patch("/{id}", spotHandler.PatchSpot)
+			r.Delete("/{id}", spotHandler.DeleteSpot)
+			r.Delete("/{id}/images/{imageId}", imageHandler.DeleteImage)
+			r.Get("/{id}/images/{imageId}/metadata", imageHandler.GetImageMetadata)
+			r.Put("/{id}/hours", spotHandler.SetOpeningHours)
+			r.Put("/{id}/equipment/{type}", spotHandler.SetSpotEquipment)
+			r.Delete("/{id}/equipment/{type}", spotHandler.RemoveSpotEquipment)
+		})
 
 		//public routes
-		r.Get("/", spotHandler.GetPublicSpotsWithDetails) // no auth, but will filter secret spots
-		r.Get("/public/category/{category}", spotHandler.GetPublicSpotsByCategoryWithDetails)
+		// no auth, but will filter secret spots. A session only adds the is_favorite flags
+		r.With(authMiddleware.OptionalAuth).Get("/", spotHandler.GetPublicSpotsWithDetails)
+		r.With(authMiddleware.OptionalAuth).Get("/public/category/{category}", spotHandler.GetPublicSpotsByCategoryWithDetails)
+		r.With(authMiddleware.OptionalAuth).Get("/nearby", spotHandler.GetSpotsNearby) // secret spots only with a session
+		r.With(authMiddleware.OptionalAuth).Get("/bbox", spotHandler.GetSpotsInBBox)
+		r.With(authMiddleware.OptionalAuth).Get("/search", spotHandler.SearchSpots)
+		r.With(authMiddleware.OptionalAuth).Get("/category/{category}.geojson", spotHandler.ExportCategoryGeoJSON)
+		r.With(authMiddleware.OptionalAuth).Get("/export.gpx", spotHandler.ExportGPX)
+		r.With(authMiddleware.OptionalAuth).Get("/export.kml", spotHandler.ExportKML)
 		r.Get("/{id}", spotHandler.GetSpotById)
+		r.With(authMiddleware.OptionalAuth).Get("/{id}/reviews", reviewHandler.GetSpotReviews)
+		r.With(authMiddleware.OptionalAuth).Get("/{id}/hours", spotHandler.GetOpeningHours)
+		r.With(authMiddleware.OptionalAuth).Get("/{id}/equipment", spotHandler.GetSpotEquipment)
 		//r.Get("/{id}/location", spotHandler.GetSpotWithLocation)
 
 		// Secret spots category - requires auth
@@ -72,12 +129,65 @@ func setupSpotRoutes(router *chi.Mux, spotHandler *handler.SpotHandler, authMidd
 			r.Use(authMiddleware.RequireAuth)
 			r.Get("/all", spotHandler.GetSpotsWithDetails)
 			r.Get("/category/{category}", spotHandler.GetSpotsByCategoryWithDetails)
+			r.Get("/{id}/images", imageHandler.GetSpotImages)
+			r.Post("/{id}/images", imageHandler.UploadSpotImage)
+			// Authors edit their own reviews, admins may delete any review
+			r.Post("/{id}/reviews", reviewHandler.CreateReview)
+			r.Put("/{id}/reviews/{reviewId}", reviewHandler.UpdateReview)
+			r.Delete("/{id}/reviews/{reviewId}", reviewHandler.DeleteReview)
+			r.Put("/{id}/favorite", spotHandler.AddFavorite)
+			r.Delete("/{id}/favorite", spotHandler.RemoveFavorite)
 			//r.Get("/category/secret", spotHandler.GetSecretSpotsByCategory)
 		})
 
 	})
 }
 
+func setupMeRoutes(router *chi.Mux, spotHandler *handler.SpotHandler, submissionHandler *handler.SubmissionHandler, languageHandler *handler.LanguageHandler, authMiddleware *AuthMiddleware) {
+	// Anyone can see which language is used, only users can save a preference
+	router.Get("/language", languageHandler.GetLanguage)
+
+	router.Group(func(router chi.Router) {
+		router.Use(authMiddleware.RequireAuth)
+		router.Get("/me/favorites", spotHandler.GetFavoriteSpots)
+		router.Get("/me/submissions", submissionHandler.GetMySubmissions)
+		router.Put("/me/language", languageHandler.SetLanguage)
+	})
+}
+
+func setupSubmissionRoutes(router *chi.Mux, submissionHandler *handler.SubmissionHandler, authMiddleware *AuthMiddleware) {
+	// Any user may propose a spot, it only becomes public after an admin approves it
+	router.Route("/submissions", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Post("/", submissionHandler.CreateSubmission)
+		r.Post("/{id}/images", submissionHandler.UploadSubmissionImage)
+	})
+
+	router.Route("/admin/submissions", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAdmin)
+		r.Get("/", submissionHandler.ListSubmissions)
+		r.Get("/{id}", submissionHandler.GetSubmission)
+		r.Put("/{id}", submissionHandler.UpdateSubmission)
+		r.Post("/{id}/approve", submissionHandler.ApproveSubmission)
+		r.Post("/{id}/reject", submissionHandler.RejectSubmission)
+	})
+}
+
+func setupCategoryRoutes(router *chi.Mux, categoryHandler *handler.CategoryHandler, authMiddleware *AuthMiddleware) {
+	router.Get("/categories", categoryHandler.GetCategories)
+
+	router.Route("/admin/categories", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAdmin)
+		r.Post("/", categoryHandler.CreateCategory)
+		r.Put("/order", categoryHandler.ReorderCategories)
+		r.Put("/{slug}", categoryHandler.UpdateCategory)
+	})
+}
+
+func setupSchemaRoutes(router *chi.Mux, schemaHandler *handler.SchemaHandler, authMiddleware *AuthMiddleware) {
+	router.With(authMiddleware.RequireAdmin).Get("/admin/schema", schemaHandler.GetSchemaStatus)
+}
+
 //func setupUserRoutes(router *chi.Mux, userHandler *handler.UserHandler, authMiddleware *authorization.AuthMiddleware) {
 //	router.Group(func(r chi.Router) {
 //		r.Use(authMiddleware.RequireAuth)
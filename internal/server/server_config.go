@@ -1,43 +1,106 @@
 package server
 
 import (
+	"PilaiteProject/db/migration"
+	"PilaiteProject/internal/config"
 	"PilaiteProject/internal/dbConfig"
+	"PilaiteProject/internal/handler"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/metrics"
+	"PilaiteProject/internal/migrate"
+	"PilaiteProject/internal/storage"
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/alexedwards/scs/pgxstore"
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	// sessionCleanupInterval controls how often expired rows are removed from the sessions table
+	sessionCleanupInterval = 30 * time.Minute
+
+	uploadURLPrefix = "/uploads/"
+)
+
 type Server struct {
 	httpServer     *http.Server
+	metricsServer  *http.Server // nil unless METRICS_ADDR is set
 	sessionManager *scs.SessionManager
+	sessionStore   *pgxstore.PostgresStore
+	drainDelay     time.Duration
+	// draining is set once Shutdown starts, /readyz answers 503 from then on
+	draining atomic.Bool
 }
 
-type ServerConfig struct {
-	Host string
-	Port string
-}
+func NewServer(serverConfiq config.ServerConfig, conn *dbConfig.Connection) (*Server, error) {
+	// Fail at startup rather than answering every page with a 404
+	indexFile := filepath.Join(serverConfiq.StaticDir, "index.html")
+	if _, err := os.Stat(indexFile); err != nil {
+		return nil, fmt.Errorf("static dir %q has no index.html: %w", serverConfiq.StaticDir, err)
+	}
+	staticDir := filepath.Join(serverConfiq.StaticDir, "static")
 
-func NewServer(serverConfiq ServerConfig, conn *dbConfig.Connection) *Server {
+	uploadStorage, err := storage.NewLocalStorage(serverConfiq.UploadDir, uploadURLPrefix)
+	if err != nil {
+		return nil, err
+	}
 
-	sessionManager := initSessionManager()
+	// Sessions are persisted in the sessions table so they survive restarts
+	// and can be shared between several instances behind a load balancer
+	sessionStore := pgxstore.NewWithCleanupInterval(conn.Pool, sessionCleanupInterval)
+	sessionManager := initSessionManager(sessionStore, serverConfiq.Session)
+
+	bundle, err := i18n.NewBundle()
+	if err != nil {
+		return nil, err
+	}
+	if serverConfiq.LocalesDir != "" {
+		if err := bundle.Load(os.DirFS(serverConfiq.LocalesDir), "."); err != nil {
+			return nil, err
+		}
+	}
+
+	registry := metrics.NewRegistry()
+	registerPoolMetrics(registry, conn.Pool)
+	registerSessionMetrics(registry, conn.Queries)
+	logins := registry.NewCounterVec("auth_logins_total", "Login attempts by result, success or failure.", "result")
 
 	router := chi.NewRouter()
 
-	applyGlobalMiddleware(router, sessionManager)
+	applyGlobalMiddleware(router, sessionManager, NewLanguageMiddleware(bundle, sessionManager), serverConfiq.CORSOrigins, newHTTPMetrics(registry))
 
 	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		http.ServeFile(w, r, "./frontend/index.html")
+		http.ServeFile(w, r, indexFile)
 	})
 
-	router.Handle("/static/*", http.StripPrefix("/static/", cacheControlFileServer(http.Dir("./frontend/static"))))
+	router.Handle("/static/*", http.StripPrefix("/static/", cacheControlFileServer(http.Dir(staticDir))))
+	router.Handle(uploadURLPrefix+"*", http.StripPrefix(uploadURLPrefix, noDirectoryListing(cacheControlFileServer(http.Dir(serverConfiq.UploadDir)))))
+
+	migrator, err := migrate.New(conn.Pool, migration.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{
+		sessionManager: sessionManager,
+		sessionStore:   sessionStore,
+		drainDelay:     serverConfiq.DrainDelay,
+	}
+	healthHandler := handler.NewHealthHandler(server.draining.Load, readinessChecks(conn.Pool, migrator, sessionStore)...)
 
-	setupRoutes(router, conn, sessionManager)
+	setupRoutes(router, conn, sessionManager, bundle, uploadStorage, migrator, healthHandler, logins, serverConfiq.KeepImageMetadata)
+	server.metricsServer = setupMetrics(router, registry, serverConfiq.Metrics)
 
 	// SPA fallback: if no other route matched (and not an API/static route), serve index.html.
 	// This lets client-side routes like /dashboard work on refresh.
@@ -49,39 +112,60 @@ func NewServer(serverConfiq ServerConfig, conn *dbConfig.Connection) *Server {
 			return
 		}
 		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		http.ServeFile(w, r, "./frontend/index.html")
+		http.ServeFile(w, r, indexFile)
 	})
 
-	addr := fmt.Sprintf("%s:%s", serverConfiq.Host, serverConfiq.Port)
-	httpServer := &http.Server{
-		Addr:    addr,
+	server.httpServer = &http.Server{
+		Addr:    serverConfiq.Addr(),
 		Handler: router,
 	}
-
-	return &Server{
-		httpServer:     httpServer,
-		sessionManager: sessionManager,
-	}
+	return server, nil
 }
 
-func initSessionManager() *scs.SessionManager {
+func initSessionManager(store scs.Store, sessionConfig config.SessionConfig) *scs.SessionManager {
 	sessionManager := scs.New()
-	sessionManager.Lifetime = 24 * time.Hour
+	sessionManager.Store = store
+	sessionManager.Lifetime = sessionConfig.Lifetime
 	sessionManager.Cookie.Name = "session_id"
-	sessionManager.Cookie.HttpOnly = true                 // Prevent JavaScript access
-	sessionManager.Cookie.Secure = false                  // Set to true in production with HTTPS
-	sessionManager.Cookie.SameSite = http.SameSiteLaxMode // CSRF protection
+	sessionManager.Cookie.HttpOnly = true                     // Prevent JavaScript access
+	sessionManager.Cookie.Secure = sessionConfig.CookieSecure // COOKIE_SECURE=true in production with HTTPS
+	sessionManager.Cookie.SameSite = http.SameSiteLaxMode     // CSRF protection
 	return sessionManager
 }
 
 func (s *Server) Start() error {
-	fmt.Printf("Server starting on %s\n", s.httpServer.Addr)
+	if s.metricsServer != nil {
+		go func() {
+			slog.Info("metrics listener starting", "addr", s.metricsServer.Addr)
+			if err := s.metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				slog.Error("metrics listener failed", "error", err)
+			}
+		}()
+	}
+
+	slog.Info("server starting", "addr", s.httpServer.Addr)
 	return s.httpServer.ListenAndServe()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	fmt.Println("Server shutting down...")
-	return s.httpServer.Shutdown(ctx)
+	slog.Info("server shutting down", "drain_delay", s.drainDelay)
+	s.draining.Store(true)
+	if s.drainDelay > 0 {
+		select {
+		case <-time.After(s.drainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	err := s.httpServer.Shutdown(ctx)
+	if s.metricsServer != nil {
+		err = errors.Join(err, s.metricsServer.Shutdown(ctx))
+	}
+
+	// Stop the cleanup goroutine only after in-flight requests are done with the store
+	s.sessionStore.StopCleanup()
+
+	return err
 }
 
 // cacheControlFileServer wraps http.FileServer and sets cache headers for assets.
@@ -98,6 +182,18 @@ func cacheControlFileServer(fs http.FileSystem) http.Handler {
 	})
 }
 
+// noDirectoryListing hides directory indexes, upload file names are random so they
+// can't be guessed, but a listing would reveal photos of secret spots
+func noDirectoryListing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // looksLikeFile tries to detect if the path is a request for a static file (has an extension).
 // If you want all unknown paths to map to index.html (even /foo.png if missing), then remove this check.
 func looksLikeFile(path string) bool {
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"PilaiteProject/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexedwards/scs/v2/memstore"
+)
+
+// TestSessionSurvivesRestart logs a user in through the first session manager and then
+// replays the returned cookie against a freshly created session manager that
+// shares the same store, which is what happens after a server restart.
+func TestSessionSurvivesRestart(t *testing.T) {
+	store := memstore.New()
+
+	// First "server" instance logs the user in
+	before := initSessionManager(store, config.Defaults().Server.Session)
+	login := before.LoadAndSave(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		before.Put(r.Context(), "userID", 42)
+		before.Put(r.Context(), "role", "user")
+		before.Put(r.Context(), "email", "test@example.com")
+	}))
+
+	rr := httptest.NewRecorder()
+	login.ServeHTTP(rr, httptest.NewRequest("POST", "/login", nil))
+
+	cookies := rr.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "session_id" {
+		t.Fatalf("expected session_id cookie, got %v", cookies)
+	}
+
+	// Second "server" instance only shares the store
+	after := initSessionManager(store, config.Defaults().Server.Session)
+	middleware := NewAuthMiddleware(after)
+
+	called := false
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Context().Value(userIDKey).(int) != 42 {
+			t.Fatalf("expected userID 42")
+		}
+	})
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.AddCookie(cookies[0])
+	rr = httptest.NewRecorder()
+
+	after.LoadAndSave(middleware.RequireAuth(nextHandler)).ServeHTTP(rr, req)
+
+	if !called {
+		t.Fatalf("session was lost after restart, got %d", rr.Code)
+	}
+}
+
+func TestSessionLostWithoutSharedStore(t *testing.T) {
+	before := initSessionManager(memstore.New(), config.Defaults().Server.Session)
+	login := before.LoadAndSave(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		before.Put(r.Context(), "userID", 42)
+	}))
+
+	rr := httptest.NewRecorder()
+	login.ServeHTTP(rr, httptest.NewRequest("POST", "/login", nil))
+	cookies := rr.Result().Cookies()
+
+	after := initSessionManager(memstore.New(), config.Defaults().Server.Session)
+	middleware := NewAuthMiddleware(after)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.AddCookie(cookies[0])
+	rr = httptest.NewRecorder()
+
+	after.LoadAndSave(middleware.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler SHOULD NOT have been called")
+	}))).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 Unauthorized, got %d", rr.Code)
+	}
+}
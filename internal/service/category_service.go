@@ -0,0 +1,278 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/interfaces"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultLanguage is the language every category must have a name in
+const DefaultLanguage = i18n.DefaultLanguage
+
+// Column sizes from the category migration
+const (
+	maxCategorySlugLength = 50
+	maxCategoryIconLength = 50
+	maxCategoryNameLength = 50
+)
+
+var (
+	categorySlugPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	languageCodePattern = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+type CategoryService struct {
+	queries interfaces.CategoryQueries
+}
+
+func NewCategoryService(queries interfaces.CategoryQueries) *CategoryService {
+	return &CategoryService{queries: queries}
+}
+
+// CategoryInput holds the editable fields of a category, Names maps language codes to display names.
+// HasEquipment lets the spots of the category list their equipment.
+type CategoryInput struct {
+	Slug         string
+	Names        map[string]string
+	Icon         string
+	RequiresAuth bool
+	HasEquipment bool
+}
+
+// GetCategories returns every category in display order
+func (s *CategoryService) GetCategories(ctx context.Context) ([]dto.CategoryDTO, error) {
+	rows, err := s.queries.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	dtos := make([]dto.CategoryDTO, len(rows))
+	for i, row := range rows {
+		category, err := toCategoryDTO(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		dtos[i] = *category
+	}
+	return dtos, nil
+}
+
+// CreateCategory adds a category at the end of the list
+func (s *CategoryService) CreateCategory(ctx context.Context, in CategoryInput) (*dto.CategoryDTO, error) {
+	if utf8.RuneCountInString(in.Slug) > maxCategorySlugLength || !categorySlugPattern.MatchString(in.Slug) {
+		return nil, newValidationError("slug may only contain letters, digits, '_' and '-' and be at most 50 characters long")
+	}
+	names, err := in.encodeNames()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.InsertCategory(ctx, db.InsertCategoryParams{
+		Slug:         in.Slug,
+		Names:        names,
+		Icon:         in.Icon,
+		RequiresAuth: in.RequiresAuth,
+		HasEquipment: in.HasEquipment,
+	})
+	// ON CONFLICT DO NOTHING returns no row for a slug that is taken
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCategoryExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert category: %w", err)
+	}
+	return toCategoryDTO(ctx, row)
+}
+
+// UpdateCategory replaces names, icon and the authentication and equipment flags. The slug is the
+// category's identifier in URLs and spot rows, so it cannot be changed here.
+func (s *CategoryService) UpdateCategory(ctx context.Context, slug string, in CategoryInput) (*dto.CategoryDTO, error) {
+	names, err := in.encodeNames()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpdateCategory(ctx, db.UpdateCategoryParams{
+		Slug:         slug,
+		Names:        names,
+		Icon:         in.Icon,
+		RequiresAuth: in.RequiresAuth,
+		HasEquipment: in.HasEquipment,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+	return toCategoryDTO(ctx, row)
+}
+
+// ReorderCategories sets the display order, slugs must list every category exactly once
+func (s *CategoryService) ReorderCategories(ctx context.Context, slugs []string) ([]dto.CategoryDTO, error) {
+	rows, err := s.queries.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	existing := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		existing[row.Slug] = true
+	}
+	seen := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		if !existing[slug] {
+			return nil, newValidationError("unknown category: %s", slug)
+		}
+		if seen[slug] {
+			return nil, newValidationError("category %s is listed twice", slug)
+		}
+		seen[slug] = true
+	}
+	if len(seen) != len(existing) {
+		return nil, newValidationError("the new order must list every category")
+	}
+
+	if err := s.queries.ReorderCategories(ctx, slugs); err != nil {
+		return nil, fmt.Errorf("failed to reorder categories: %w", err)
+	}
+	return s.GetCategories(ctx)
+}
+
+func (in CategoryInput) encodeNames() ([]byte, error) {
+	if in.Names[DefaultLanguage] == "" {
+		return nil, newValidationError("a name in the default language (%s) is required", DefaultLanguage)
+	}
+	for language, name := range in.Names {
+		if !languageCodePattern.MatchString(language) {
+			return nil, newValidationError("invalid language code: %s", language)
+		}
+		if name == "" || utf8.RuneCountInString(name) > maxCategoryNameLength {
+			return nil, newValidationError("name must be between 1 and %d characters long", maxCategoryNameLength)
+		}
+	}
+	if utf8.RuneCountInString(in.Icon) > maxCategoryIconLength {
+		return nil, newValidationError("icon cannot be longer than %d characters", maxCategoryIconLength)
+	}
+
+	names, err := json.Marshal(in.Names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode category names: %w", err)
+	}
+	return names, nil
+}
+
+// categoryLookup is the part of the query interfaces needed to resolve a category slug
+type categoryLookup interface {
+	GetCategoryBySlug(ctx context.Context, slug string) (db.Category, error)
+}
+
+// checkCategory makes sure the slug names an existing category
+func checkCategory(ctx context.Context, q categoryLookup, slug string) (db.Category, error) {
+	category, err := q.GetCategoryBySlug(ctx, slug)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Category{}, newValidationError("Invalid category: %v", slug)
+	}
+	if err != nil {
+		return db.Category{}, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// spotVisible reports whether the spot may be shown. Spots in categories that require
+// authentication are hidden unless includeSecret is set, i.e. for logged in users.
+func spotVisible(ctx context.Context, q categoryLookup, spot db.Spot, includeSecret bool) (bool, error) {
+	if includeSecret {
+		return true, nil
+	}
+	category, err := q.GetCategoryBySlug(ctx, spot.Category)
+	if err != nil {
+		return false, fmt.Errorf("failed to get category: %w", err)
+	}
+	return !category.RequiresAuth, nil
+}
+
+// categoryLister is the part of the query interfaces needed to name categories
+type categoryLister interface {
+	GetCategories(ctx context.Context) ([]db.Category, error)
+}
+
+// categoryNames maps every category slug to its name in the language of the request
+func categoryNames(ctx context.Context, q categoryLister) (map[string]string, error) {
+	rows, err := q.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	language := i18n.Language(ctx)
+	names := make(map[string]string, len(rows))
+	for _, row := range rows {
+		translations, err := decodeCategoryNames(row)
+		if err != nil {
+			return nil, err
+		}
+		names[row.Slug] = localizedCategoryName(row.Slug, translations, language)
+	}
+	return names, nil
+}
+
+// nameCategories sets CategoryName on the cards
+func (s *SpotService) nameCategories(ctx context.Context, cards []dto.SpotCardDTO) error {
+	if len(cards) == 0 {
+		return nil
+	}
+	names, err := categoryNames(ctx, s.queries)
+	if err != nil {
+		return err
+	}
+	for i := range cards {
+		cards[i].CategoryName = names[cards[i].Category]
+	}
+	return nil
+}
+
+// localizedCategoryName falls back to the default language and then to the slug
+func localizedCategoryName(slug string, names map[string]string, language string) string {
+	if name := names[language]; name != "" {
+		return name
+	}
+	if name := names[DefaultLanguage]; name != "" {
+		return name
+	}
+	return slug
+}
+
+func decodeCategoryNames(row db.Category) (map[string]string, error) {
+	names := map[string]string{}
+	if err := json.Unmarshal(row.Names, &names); err != nil {
+		return nil, fmt.Errorf("failed to decode names of category %s: %w", row.Slug, err)
+	}
+	return names, nil
+}
+
+// toCategoryDTO names the category in the language of the request and keeps every
+// translation in Names for admin forms
+func toCategoryDTO(ctx context.Context, row db.Category) (*dto.CategoryDTO, error) {
+	names, err := decodeCategoryNames(row)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CategoryDTO{
+		Slug:         row.Slug,
+		Name:         localizedCategoryName(row.Slug, names, i18n.Language(ctx)),
+		Names:        names,
+		Icon:         row.Icon,
+		SortOrder:    row.SortOrder,
+		RequiresAuth: row.RequiresAuth,
+		HasEquipment: row.HasEquipment,
+	}, nil
+}
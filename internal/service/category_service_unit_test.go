@@ -0,0 +1,183 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// seededCategories mirrors the rows added by the category migration
+func seededCategories() []db.Category {
+	return []db.Category{
+		{Slug: "Gamta", Names: []byte(`{"lt": "Gamta", "en": "Nature"}`), SortOrder: 1},
+		{Slug: "Lauko_treniruokliai", Names: []byte(`{"lt": "Lauko treniruokliai"}`), SortOrder: 2, HasEquipment: true},
+		{Slug: "Slaptos_vietos", Names: []byte(`{"lt": "Slaptos vietos"}`), SortOrder: 3, RequiresAuth: true},
+		{Slug: "Restoranai", Names: []byte(`{"lt": "Restoranai"}`), SortOrder: 4},
+		{Slug: "Parduotuves", Names: []byte(`{"lt": "Parduotuvės"}`), SortOrder: 5},
+	}
+}
+
+func listSeededCategories(ctx context.Context) ([]db.Category, error) {
+	return seededCategories(), nil
+}
+
+func seededCategoryBySlug(ctx context.Context, slug string) (db.Category, error) {
+	for _, category := range seededCategories() {
+		if category.Slug == slug {
+			return category, nil
+		}
+	}
+	return db.Category{}, pgx.ErrNoRows
+}
+
+func TestGetCategories_DecodesNames(t *testing.T) {
+	mock := &mocks.MockCategoryQueries{
+		GetCategoriesFunc: func(ctx context.Context) ([]db.Category, error) {
+			return seededCategories()[:1], nil
+		},
+	}
+
+	svc := NewCategoryService(mock)
+
+	categories, err := svc.GetCategories(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(categories) != 1 || categories[0].Name != "Gamta" || categories[0].Names["en"] != "Nature" {
+		t.Fatalf("unexpected categories: %+v", categories)
+	}
+}
+
+func TestCreateCategory_Success(t *testing.T) {
+	mock := &mocks.MockCategoryQueries{
+		InsertCategoryFunc: func(ctx context.Context, arg db.InsertCategoryParams) (db.Category, error) {
+			if arg.Slug != "Muziejai" || !arg.RequiresAuth {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			return db.Category{Slug: arg.Slug, Names: arg.Names, Icon: arg.Icon, SortOrder: 6, RequiresAuth: arg.RequiresAuth}, nil
+		},
+	}
+
+	svc := NewCategoryService(mock)
+
+	category, err := svc.CreateCategory(context.Background(), CategoryInput{
+		Slug:         "Muziejai",
+		Names:        map[string]string{"lt": "Muziejai", "en": "Museums"},
+		Icon:         "landmark",
+		RequiresAuth: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if category.Name != "Muziejai" || category.SortOrder != 6 {
+		t.Fatalf("unexpected category: %+v", category)
+	}
+}
+
+func TestCreateCategory_Exists(t *testing.T) {
+	mock := &mocks.MockCategoryQueries{
+		InsertCategoryFunc: func(ctx context.Context, arg db.InsertCategoryParams) (db.Category, error) {
+			return db.Category{}, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewCategoryService(mock)
+
+	_, err := svc.CreateCategory(context.Background(), CategoryInput{
+		Slug:  "Gamta",
+		Names: map[string]string{"lt": "Gamta"},
+	})
+	if !errors.Is(err, ErrCategoryExists) {
+		t.Fatalf("expected ErrCategoryExists, got %v", err)
+	}
+}
+
+func TestCreateCategory_InvalidInput(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewCategoryService(&mocks.MockCategoryQueries{})
+
+	inputs := []CategoryInput{
+		{Slug: "su tarpu", Names: map[string]string{"lt": "Su tarpu"}},
+		{Slug: "", Names: map[string]string{"lt": "Tuščias"}},
+		{Slug: "Muziejai", Names: map[string]string{"en": "Museums"}},
+		{Slug: "Muziejai", Names: map[string]string{"lt": "Muziejai", "english": "Museums"}},
+	}
+	for _, in := range inputs {
+		_, err := svc.CreateCategory(context.Background(), in)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected validation error for %+v, got %v", in, err)
+		}
+	}
+}
+
+func TestUpdateCategory_NotFound(t *testing.T) {
+	mock := &mocks.MockCategoryQueries{
+		UpdateCategoryFunc: func(ctx context.Context, arg db.UpdateCategoryParams) (db.Category, error) {
+			return db.Category{}, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewCategoryService(mock)
+
+	_, err := svc.UpdateCategory(context.Background(), "Muziejai", CategoryInput{Names: map[string]string{"lt": "Muziejai"}})
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestReorderCategories_MustListEverySlug(t *testing.T) {
+	mock := &mocks.MockCategoryQueries{
+		GetCategoriesFunc: func(ctx context.Context) ([]db.Category, error) {
+			return seededCategories(), nil
+		},
+		ReorderCategoriesFunc: func(ctx context.Context, slugs []string) error {
+			t.Fatal("an incomplete order must not be saved")
+			return nil
+		},
+	}
+
+	svc := NewCategoryService(mock)
+
+	orders := [][]string{
+		{"Gamta", "Restoranai"},
+		{"Gamta", "Gamta", "Lauko_treniruokliai", "Slaptos_vietos", "Restoranai"},
+		{"Gamta", "Lauko_treniruokliai", "Slaptos_vietos", "Restoranai", "Parduotuves", "Muziejai"},
+	}
+	for _, order := range orders {
+		_, err := svc.ReorderCategories(context.Background(), order)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected validation error for %v, got %v", order, err)
+		}
+	}
+}
+
+func TestReorderCategories_Success(t *testing.T) {
+	var saved []string
+	mock := &mocks.MockCategoryQueries{
+		GetCategoriesFunc: func(ctx context.Context) ([]db.Category, error) {
+			return seededCategories(), nil
+		},
+		ReorderCategoriesFunc: func(ctx context.Context, slugs []string) error {
+			saved = slugs
+			return nil
+		},
+	}
+
+	svc := NewCategoryService(mock)
+
+	order := []string{"Parduotuves", "Restoranai", "Slaptos_vietos", "Lauko_treniruokliai", "Gamta"}
+	if _, err := svc.ReorderCategories(context.Background(), order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(saved) != 5 || saved[0] != "Parduotuves" {
+		t.Fatalf("unexpected saved order: %v", saved)
+	}
+}
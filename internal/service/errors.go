@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSpotNotFound  = errors.New("spot not found")
+	ErrImageNotFound = errors.New("image not found")
+
+	ErrReviewNotFound = errors.New("review not found")
+	ErrReviewExists   = errors.New("you have already reviewed this spot")
+
+	ErrEquipmentNotFound = errors.New("equipment not found")
+
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("a category with this slug already exists")
+
+	ErrSubmissionNotFound   = errors.New("submission not found")
+	ErrSubmissionNotPending = errors.New("submission has already been moderated")
+
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("a user with this email already exists")
+
+	// ErrForbidden is returned when the user is logged in but may not touch the resource
+	ErrForbidden = errors.New("forbidden")
+)
+
+// ValidationError is returned when the input itself is wrong, so handlers can answer with 400.
+// Format and Args are kept next to the English Message so handlers can translate it.
+type ValidationError struct {
+	Message string
+	Format  string
+	Args    []any
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func newValidationError(format string, args ...any) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...), Format: format, Args: args}
+}
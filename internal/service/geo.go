@@ -0,0 +1,30 @@
+package service
+
+import "math"
+
+const (
+	earthRadiusM = 6371000.0
+	// metersPerDegreeLat is the length of one degree of latitude, it barely changes with latitude
+	metersPerDegreeLat = math.Pi * earthRadiusM / 180
+)
+
+// boundingBox returns the smallest lat/lng box that contains the circle around the point.
+// It is only a prefilter, so being a little too big is fine. Boxes crossing the
+// antimeridian are clamped instead of wrapped, which is irrelevant for Lithuania.
+func boundingBox(lat, lng, radiusM float64) (minLat, maxLat, minLng, maxLng float64) {
+	deltaLat := radiusM / metersPerDegreeLat
+	minLat = math.Max(lat-deltaLat, -90)
+	maxLat = math.Min(lat+deltaLat, 90)
+
+	// Near the poles a circle can cover every longitude
+	cosLat := math.Cos(lat * math.Pi / 180)
+	if cosLat < 1e-6 || minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, -180, 180
+	}
+
+	deltaLng := radiusM / (metersPerDegreeLat * cosLat)
+	if deltaLng >= 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, math.Max(lng-deltaLng, -180), math.Min(lng+deltaLng, 180)
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/imaging"
+	"PilaiteProject/internal/interfaces"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// MaxImageUploadSize is the largest accepted image file in bytes
+	MaxImageUploadSize = 10 << 20
+
+	thumbnailSize = 320
+	displaySize   = 1600
+)
+
+// allowedImageTypes lists content types accepted for upload
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+type ImageService struct {
+	queries interfaces.ImageQueries
+	storage interfaces.FileStorage
+	// keepMetadata stores the stripped EXIF/XMP/IPTC blocks in the admin only original_metadata column
+	keepMetadata bool
+}
+
+func NewImageService(queries interfaces.ImageQueries, storage interfaces.FileStorage, keepMetadata bool) *ImageService {
+	return &ImageService{queries: queries, storage: storage, keepMetadata: keepMetadata}
+}
+
+// StoredImage is an uploaded photo after its variants were written to storage
+type StoredImage struct {
+	URL          string
+	ThumbnailURL string
+	// OriginalMetadata is only set when metadata is kept for admins
+	OriginalMetadata []byte
+}
+
+// UploadSpotImage validates the uploaded file, stores a display and a thumbnail variant
+// and records them in the image table. Stored variants are re-encoded from pixels only,
+// so EXIF (including GPS), XMP and IPTC metadata never reaches the public files.
+func (s *ImageService) UploadSpotImage(ctx context.Context, spotID int64, contentType string, data []byte) (*dto.ImageDTO, error) {
+	// Cheap checks first, so a bad file never costs a query
+	if err := validateUpload(contentType, data); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.GetSpotByID(ctx, spotID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSpotNotFound
+		}
+		return nil, fmt.Errorf("failed to get spot: %w", err)
+	}
+
+	stored, err := s.StoreUpload(contentType, data, fmt.Sprintf("spots/%d", spotID))
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.InsertImage(ctx, db.InsertImageParams{
+		Url:              stored.URL,
+		ThumbnailUrl:     stored.ThumbnailURL,
+		SpotID:           spotID,
+		OriginalMetadata: stored.OriginalMetadata,
+	})
+	if err != nil {
+		s.DeleteStoredImage(stored)
+		return nil, fmt.Errorf("failed to insert image: %w", err)
+	}
+
+	return toImageDTO(row), nil
+}
+
+// StoreUpload validates an uploaded photo and writes its display and thumbnail variants
+// below dir. The caller records the result and calls DeleteStoredImage if that fails.
+func (s *ImageService) StoreUpload(contentType string, data []byte, dir string) (*StoredImage, error) {
+	if err := validateUpload(contentType, data); err != nil {
+		return nil, err
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return nil, newValidationError("invalid image: %v", err)
+	}
+
+	stored := &StoredImage{}
+	if s.keepMetadata {
+		if meta := imaging.ExtractMetadata(data, format); !meta.Empty() {
+			stored.OriginalMetadata, err = json.Marshal(meta)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode metadata: %w", err)
+			}
+		}
+	}
+
+	baseName, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	baseName = dir + "/" + baseName
+
+	stored.URL, err = s.saveVariant(img, format, displaySize, baseName+"_display")
+	if err != nil {
+		return nil, err
+	}
+	stored.ThumbnailURL, err = s.saveVariant(img, format, thumbnailSize, baseName+"_thumb")
+	if err != nil {
+		s.removeFiles(stored.URL)
+		return nil, err
+	}
+	return stored, nil
+}
+
+// DeleteStoredImage removes the files of an image that never made it into the database
+func (s *ImageService) DeleteStoredImage(stored *StoredImage) {
+	s.removeFiles(stored.URL, stored.ThumbnailURL)
+}
+
+func (s *ImageService) GetSpotImages(ctx context.Context, spotID int64) ([]dto.ImageDTO, error) {
+	rows, err := s.queries.GetImagesBySpotID(ctx, spotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get images: %w", err)
+	}
+
+	dtos := make([]dto.ImageDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = *toImageDTO(row)
+	}
+	return dtos, nil
+}
+
+// GetImageMetadata returns the metadata that was stripped from the uploaded file, admins only
+func (s *ImageService) GetImageMetadata(ctx context.Context, spotID, imageID int64) (json.RawMessage, error) {
+	row, err := s.queries.GetImageByID(ctx, imageID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && row.SpotID != spotID) {
+		return nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if row.OriginalMetadata == nil {
+		return json.RawMessage("{}"), nil
+	}
+	return json.RawMessage(row.OriginalMetadata), nil
+}
+
+// DeleteImage removes the image row and the files of all its variants
+func (s *ImageService) DeleteImage(ctx context.Context, spotID, imageID int64) error {
+	row, err := s.queries.DeleteImage(ctx, db.DeleteImageParams{ID: imageID, SpotID: spotID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrImageNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	s.DeleteImageFiles([]db.Image{row})
+	return nil
+}
+
+// DeleteImageFiles removes stored files of images whose rows are already gone.
+// Failures are only logged, a leftover file must not fail the request.
+func (s *ImageService) DeleteImageFiles(images []db.Image) {
+	for _, row := range images {
+		s.removeFiles(row.Url, row.ThumbnailUrl)
+	}
+}
+
+// validateUpload checks size and type before anything is decoded
+func validateUpload(contentType string, data []byte) error {
+	if len(data) == 0 {
+		return newValidationError("image file is empty")
+	}
+	if len(data) > MaxImageUploadSize {
+		return newValidationError("image cannot be larger than %d MB", MaxImageUploadSize>>20)
+	}
+	// The declared type comes from the client, so the content itself is checked as well
+	if !allowedImageTypes[contentType] || !allowedImageTypes[http.DetectContentType(data)] {
+		return newValidationError("only JPEG and PNG images are allowed")
+	}
+	return nil
+}
+
+func (s *ImageService) saveVariant(img image.Image, format string, size int, name string) (string, error) {
+	data, err := imaging.Encode(imaging.Fit(img, size, size), format)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := s.storage.Save(name+imaging.Extension(format), data)
+	if err != nil {
+		return "", fmt.Errorf("failed to store image: %w", err)
+	}
+	return url, nil
+}
+
+func (s *ImageService) removeFiles(urls ...string) {
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+		if err := s.storage.Delete(url); err != nil {
+			slog.Warn("failed to remove image file", "url", url, "error", err)
+		}
+	}
+}
+
+// randomName returns an unguessable file name, so images of secret spots can't be enumerated
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate file name: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func toImageDTO(row db.Image) *dto.ImageDTO {
+	return &dto.ImageDTO{
+		ID:           row.ID,
+		SpotID:       row.SpotID,
+		URL:          row.Url,
+		ThumbnailURL: row.ThumbnailUrl,
+	}
+}
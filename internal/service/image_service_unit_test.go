@@ -0,0 +1,182 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/mocks"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withXMPChunk inserts a tEXt chunk with XMP right after the PNG header chunk
+func withXMPChunk(data []byte, xmp string) []byte {
+	body := append([]byte("XML:com.adobe.xmp\x00"), xmp...)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(body)))
+	chunk = append(chunk, "tEXt"...)
+	chunk = append(chunk, body...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(append([]byte("tEXt"), body...)))
+
+	// 8 byte signature + IHDR chunk (4 length + 4 type + 13 data + 4 crc)
+	headerEnd := 8 + 25
+	result := append([]byte{}, data[:headerEnd]...)
+	result = append(result, chunk...)
+	return append(result, data[headerEnd:]...)
+}
+
+func TestUploadSpotImage_Success(t *testing.T) {
+	mock := &mocks.MockImageQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id}, nil
+		},
+		InsertImageFunc: func(ctx context.Context, arg db.InsertImageParams) (db.Image, error) {
+			return db.Image{ID: 1, Url: arg.Url, ThumbnailUrl: arg.ThumbnailUrl, SpotID: arg.SpotID}, nil
+		},
+	}
+	files := mocks.NewMockFileStorage()
+
+	svc := NewImageService(mock, files, false)
+
+	img, err := svc.UploadSpotImage(context.Background(), 4, "image/png", testPNG(t, 2000, 1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(files.Files) != 2 {
+		t.Fatalf("expected 2 stored variants, got %d", len(files.Files))
+	}
+
+	thumb, _, err := image.DecodeConfig(bytes.NewReader(files.Files[img.ThumbnailURL]))
+	if err != nil {
+		t.Fatalf("thumbnail is not a valid image: %v", err)
+	}
+	if thumb.Width != thumbnailSize || thumb.Height != thumbnailSize/2 {
+		t.Fatalf("expected %dx%d thumbnail, got %dx%d", thumbnailSize, thumbnailSize/2, thumb.Width, thumb.Height)
+	}
+
+	display, _, err := image.DecodeConfig(bytes.NewReader(files.Files[img.URL]))
+	if err != nil {
+		t.Fatalf("display variant is not a valid image: %v", err)
+	}
+	if display.Width != displaySize {
+		t.Fatalf("expected display width %d, got %d", displaySize, display.Width)
+	}
+}
+
+func TestUploadSpotImage_RejectsNonImage(t *testing.T) {
+	svc := NewImageService(&mocks.MockImageQueries{}, mocks.NewMockFileStorage(), false)
+
+	_, err := svc.UploadSpotImage(context.Background(), 4, "image/png", []byte("<html>not an image</html>"))
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestUploadSpotImage_SpotNotFound(t *testing.T) {
+	mock := &mocks.MockImageQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{}, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewImageService(mock, mocks.NewMockFileStorage(), false)
+
+	_, err := svc.UploadSpotImage(context.Background(), 4, "image/png", testPNG(t, 10, 10))
+	if !errors.Is(err, ErrSpotNotFound) {
+		t.Fatalf("expected ErrSpotNotFound, got %v", err)
+	}
+}
+
+func TestUploadSpotImage_RemovesFilesWhenInsertFails(t *testing.T) {
+	mock := &mocks.MockImageQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id}, nil
+		},
+		InsertImageFunc: func(ctx context.Context, arg db.InsertImageParams) (db.Image, error) {
+			return db.Image{}, errors.New("db error")
+		},
+	}
+	files := mocks.NewMockFileStorage()
+
+	svc := NewImageService(mock, files, false)
+
+	if _, err := svc.UploadSpotImage(context.Background(), 4, "image/png", testPNG(t, 10, 10)); err == nil {
+		t.Fatal("expected error from DB, got nil")
+	}
+	if len(files.Files) != 0 {
+		t.Fatalf("expected stored files to be removed, %d left", len(files.Files))
+	}
+}
+
+func TestDeleteImage_RemovesFiles(t *testing.T) {
+	files := mocks.NewMockFileStorage()
+	files.Files["/uploads/a_display.png"] = []byte{1}
+	files.Files["/uploads/a_thumb.png"] = []byte{1}
+
+	mock := &mocks.MockImageQueries{
+		DeleteImageFunc: func(ctx context.Context, arg db.DeleteImageParams) (db.Image, error) {
+			return db.Image{ID: arg.ID, SpotID: arg.SpotID, Url: "/uploads/a_display.png", ThumbnailUrl: "/uploads/a_thumb.png"}, nil
+		},
+	}
+
+	svc := NewImageService(mock, files, false)
+
+	if err := svc.DeleteImage(context.Background(), 4, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files.Files) != 0 {
+		t.Fatalf("expected files to be removed, %d left", len(files.Files))
+	}
+}
+
+func TestUploadSpotImage_StripsAndKeepsMetadata(t *testing.T) {
+	var stored db.InsertImageParams
+	mock := &mocks.MockImageQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id}, nil
+		},
+		InsertImageFunc: func(ctx context.Context, arg db.InsertImageParams) (db.Image, error) {
+			stored = arg
+			return db.Image{ID: 1, Url: arg.Url, ThumbnailUrl: arg.ThumbnailUrl, SpotID: arg.SpotID}, nil
+		},
+	}
+	files := mocks.NewMockFileStorage()
+
+	svc := NewImageService(mock, files, true)
+
+	upload := withXMPChunk(testPNG(t, 10, 10), "<exif:GPSLatitude>54.7070</exif:GPSLatitude>")
+	if _, err := svc.UploadSpotImage(context.Background(), 4, "image/png", upload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for url, data := range files.Files {
+		if bytes.Contains(data, []byte("GPSLatitude")) {
+			t.Fatalf("metadata leaked into stored file %s", url)
+		}
+	}
+	if !bytes.Contains(stored.OriginalMetadata, []byte("GPSLatitude")) {
+		t.Fatalf("expected original metadata to be kept, got %s", stored.OriginalMetadata)
+	}
+}
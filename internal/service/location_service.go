@@ -32,16 +32,8 @@ func (s *LocationService) GetAllLocations(ctx context.Context) ([]db.Location, e
 
 func (s *LocationService) InsertLocation(ctx context.Context, address string, latitude, longitude float64) (*db.Location, error) {
 	// Basic validation
-	if address == "" {
-		return nil, fmt.Errorf("address cannot be empty")
-	}
-	// Validate latitude range (-90 to 90)
-	if latitude < -90 || latitude > 90 {
-		return nil, fmt.Errorf("latitude must be between -90 and 90, got: %f", latitude)
-	}
-	// Validate longitude range (-180 to 180)
-	if longitude < -180 || longitude > 180 {
-		return nil, fmt.Errorf("longitude must be between -180 and 180, got: %f", longitude)
+	if err := validateLocationFields(address, latitude, longitude); err != nil {
+		return nil, err
 	}
 
 	location, err := s.queries.InsertLocation(ctx, db.InsertLocationParams{
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"fmt"
+	"sort"
+	"time"
+	_ "time/tzdata" // the server image does not have to ship a zoneinfo database
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// OpeningHoursTimezone is the zone every opening time is given in
+	OpeningHoursTimezone = "Europe/Vilnius"
+
+	minutesPerDay = 24 * 60
+	dateLayout    = "2006-01-02"
+
+	// openingHoursHorizonDays limits how far ahead the next change is looked for
+	openingHoursHorizonDays = 14
+)
+
+var openingHoursLocation = mustLoadLocation(OpeningHoursTimezone)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load timezone %s: %v", name, err))
+	}
+	return loc
+}
+
+// openingShift is one opening period in minutes after midnight of the day it opens on.
+// Closes is at most minutesPerDay, a shift with closes <= opens ends on the next day.
+type openingShift struct {
+	Opens  int
+	Closes int
+}
+
+// openingSchedule holds the weekly shifts of a spot (index 1 is Monday) and the dates
+// whose shifts are replaced, an empty slice there means closed the whole day
+type openingSchedule struct {
+	Weekly     [8][]openingShift
+	Exceptions map[string][]openingShift
+}
+
+type openingInterval struct {
+	start, end time.Time
+}
+
+// Status reports whether the schedule is open at the given moment and when that changes.
+// Overnight shifts spill into the next day even when that day is an exception date.
+func (sc *openingSchedule) Status(at time.Time) (bool, *time.Time) {
+	at = at.In(openingHoursLocation)
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, openingHoursLocation)
+
+	// Yesterday is included for its overnight shifts
+	var intervals []openingInterval
+	for i := -1; i <= openingHoursHorizonDays; i++ {
+		day := today.AddDate(0, 0, i)
+		for _, shift := range sc.shiftsOn(day) {
+			intervals = append(intervals, shift.interval(day))
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	// Split shifts that touch or overlap are one open period, e.g. 22:00-24:00 and 00:00-02:00
+	horizon := today.AddDate(0, 0, openingHoursHorizonDays+1)
+	for i := 0; i < len(intervals); i++ {
+		current := intervals[i]
+		for i+1 < len(intervals) && !intervals[i+1].start.After(current.end) {
+			if intervals[i+1].end.After(current.end) {
+				current.end = intervals[i+1].end
+			}
+			i++
+		}
+
+		if !current.end.After(at) {
+			continue
+		}
+		if current.start.After(at) {
+			return false, &current.start
+		}
+		if !current.end.Before(horizon) {
+			return true, nil
+		}
+		return true, &current.end
+	}
+	return false, nil
+}
+
+func (sc *openingSchedule) statusDTO(at time.Time) *dto.OpeningStatusDTO {
+	isOpen, next := sc.Status(at)
+	return &dto.OpeningStatusDTO{IsOpen: isOpen, NextChange: next}
+}
+
+func (sc *openingSchedule) shiftsOn(day time.Time) []openingShift {
+	if shifts, ok := sc.Exceptions[day.Format(dateLayout)]; ok {
+		return shifts
+	}
+	return sc.Weekly[isoWeekday(day)]
+}
+
+// interval places the shift on a calendar day. time.Date normalizes minutes past
+// midnight into the next day and keeps wall clock times across DST changes.
+func (s openingShift) interval(day time.Time) openingInterval {
+	closes := s.Closes
+	if closes <= s.Opens {
+		closes += minutesPerDay
+	}
+	return openingInterval{
+		start: time.Date(day.Year(), day.Month(), day.Day(), 0, s.Opens, 0, 0, openingHoursLocation),
+		end:   time.Date(day.Year(), day.Month(), day.Day(), 0, closes, 0, 0, openingHoursLocation),
+	}
+}
+
+// isoWeekday numbers Monday as 1 and Sunday as 7
+func isoWeekday(t time.Time) int {
+	return (int(t.Weekday())+6)%7 + 1
+}
+
+// buildOpeningSchedules groups the rows by spot. Exceptions only apply to spots that
+// have weekly hours, a spot without them has no schedule at all.
+func buildOpeningSchedules(hours []db.OpeningHour, exceptions []db.OpeningHoursException) map[int64]*openingSchedule {
+	schedules := make(map[int64]*openingSchedule)
+	for _, row := range hours {
+		schedule, ok := schedules[row.SpotID]
+		if !ok {
+			schedule = &openingSchedule{Exceptions: map[string][]openingShift{}}
+			schedules[row.SpotID] = schedule
+		}
+		schedule.Weekly[row.Weekday] = append(schedule.Weekly[row.Weekday], openingShift{
+			Opens:  timeToMinutes(row.Opens),
+			Closes: timeToMinutes(row.Closes),
+		})
+	}
+
+	for _, row := range exceptions {
+		schedule, ok := schedules[row.SpotID]
+		if !ok {
+			continue
+		}
+		date := row.Date.Time.Format(dateLayout)
+		shifts := schedule.Exceptions[date]
+		if row.Opens.Valid && row.Closes.Valid {
+			shifts = append(shifts, openingShift{
+				Opens:  timeToMinutes(row.Opens),
+				Closes: timeToMinutes(row.Closes),
+			})
+		}
+		// A closed day is stored as an empty, non nil slice
+		if shifts == nil {
+			shifts = []openingShift{}
+		}
+		schedule.Exceptions[date] = shifts
+	}
+	return schedules
+}
+
+// exceptionDateRange covers every date whose shifts matter for Status at the given moment
+func exceptionDateRange(at time.Time) (pgtype.Date, pgtype.Date) {
+	at = at.In(openingHoursLocation)
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	return pgtype.Date{Time: today.AddDate(0, 0, -1), Valid: true},
+		pgtype.Date{Time: today.AddDate(0, 0, openingHoursHorizonDays), Valid: true}
+}
+
+// openFilterParams is the Europe/Vilnius date and wall clock time the spot_is_open
+// SQL function checks the schedules against
+func openFilterParams(at time.Time) (pgtype.Date, pgtype.Time) {
+	at = at.In(openingHoursLocation)
+	date := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	clock := time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute +
+		time.Duration(at.Second())*time.Second + time.Duration(at.Nanosecond())
+	return pgtype.Date{Time: date, Valid: true},
+		pgtype.Time{Microseconds: clock.Microseconds(), Valid: true}
+}
+
+func timeToMinutes(t pgtype.Time) int {
+	return int(t.Microseconds / int64(time.Minute/time.Microsecond))
+}
+
+func minutesToTime(minutes int) pgtype.Time {
+	return pgtype.Time{Microseconds: int64(minutes) * int64(time.Minute/time.Microsecond), Valid: true}
+}
+
+// formatClock prints minutes after midnight as HH:MM, midnight at the end of a day is 24:00
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// parseClock reads HH:MM, 24:00 is only accepted when allowMidnightEnd is set
+func parseClock(value string, allowMidnightEnd bool) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if value == "24:00" && allowMidnightEnd {
+		return minutesPerDay, nil
+	}
+	if err != nil || len(value) != 5 {
+		return 0, newValidationError("invalid time %q, expected HH:MM", value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
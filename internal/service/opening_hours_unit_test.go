@@ -0,0 +1,252 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func vilniusTime(t *testing.T, value string) time.Time {
+	at, err := time.ParseInLocation("2006-01-02 15:04", value, openingHoursLocation)
+	if err != nil {
+		t.Fatalf("invalid test time %q: %v", value, err)
+	}
+	return at
+}
+
+func hoursRow(spotID int64, weekday int16, opens, closes string) db.OpeningHour {
+	return db.OpeningHour{
+		SpotID:  spotID,
+		Weekday: weekday,
+		Opens:   clockTime(opens),
+		Closes:  clockTime(closes),
+	}
+}
+
+func clockTime(value string) pgtype.Time {
+	minutes, err := parseClock(value, true)
+	if err != nil {
+		panic(err)
+	}
+	return minutesToTime(minutes)
+}
+
+func assertStatus(t *testing.T, schedule *openingSchedule, at string, wantOpen bool, wantNext string) {
+	t.Helper()
+	isOpen, next := schedule.Status(vilniusTime(t, at))
+	if isOpen != wantOpen {
+		t.Fatalf("at %s: expected open=%v, got %v", at, wantOpen, isOpen)
+	}
+	if wantNext == "" {
+		if next != nil {
+			t.Fatalf("at %s: expected no next change, got %v", at, next)
+		}
+		return
+	}
+	if next == nil || !next.Equal(vilniusTime(t, wantNext)) {
+		t.Fatalf("at %s: expected next change %s, got %v", at, wantNext, next)
+	}
+}
+
+func TestOpeningStatus_SplitShift(t *testing.T) {
+	// 2024-06-03 is a Monday
+	schedule := buildOpeningSchedules([]db.OpeningHour{
+		hoursRow(1, 1, "09:00", "13:00"),
+		hoursRow(1, 1, "14:00", "18:00"),
+	}, nil)[1]
+
+	assertStatus(t, schedule, "2024-06-03 08:00", false, "2024-06-03 09:00")
+	assertStatus(t, schedule, "2024-06-03 10:00", true, "2024-06-03 13:00")
+	assertStatus(t, schedule, "2024-06-03 13:30", false, "2024-06-03 14:00")
+	// Closed the rest of the week, so the next opening is the following Monday
+	assertStatus(t, schedule, "2024-06-03 18:00", false, "2024-06-10 09:00")
+}
+
+func TestOpeningStatus_OvernightShift(t *testing.T) {
+	// Friday 20:00 until Saturday 02:00, Saturday 22:00 until midnight
+	schedule := buildOpeningSchedules([]db.OpeningHour{
+		hoursRow(1, 5, "20:00", "02:00"),
+		hoursRow(1, 6, "22:00", "24:00"),
+	}, nil)[1]
+
+	assertStatus(t, schedule, "2024-06-08 01:00", true, "2024-06-08 02:00")
+	assertStatus(t, schedule, "2024-06-08 03:00", false, "2024-06-08 22:00")
+	assertStatus(t, schedule, "2024-06-08 23:59", true, "2024-06-09 00:00")
+}
+
+func TestOpeningStatus_AdjacentShiftsMerge(t *testing.T) {
+	// Open from Monday 22:00 straight through to Tuesday 02:00
+	schedule := buildOpeningSchedules([]db.OpeningHour{
+		hoursRow(1, 1, "22:00", "24:00"),
+		hoursRow(1, 2, "00:00", "02:00"),
+	}, nil)[1]
+
+	assertStatus(t, schedule, "2024-06-03 23:00", true, "2024-06-04 02:00")
+}
+
+func TestOpeningStatus_ExceptionDates(t *testing.T) {
+	hours := []db.OpeningHour{
+		hoursRow(1, 1, "09:00", "18:00"),
+		hoursRow(1, 2, "09:00", "18:00"),
+	}
+	exceptions := []db.OpeningHoursException{
+		// Closed on Monday 2024-06-24 (Joninės)
+		{SpotID: 1, Date: pgtype.Date{Time: time.Date(2024, 6, 24, 0, 0, 0, 0, time.UTC), Valid: true}},
+		// Short day on Tuesday
+		{
+			SpotID: 1,
+			Date:   pgtype.Date{Time: time.Date(2024, 6, 25, 0, 0, 0, 0, time.UTC), Valid: true},
+			Opens:  clockTime("10:00"),
+			Closes: clockTime("14:00"),
+		},
+	}
+	schedule := buildOpeningSchedules(hours, exceptions)[1]
+
+	assertStatus(t, schedule, "2024-06-24 12:00", false, "2024-06-25 10:00")
+	assertStatus(t, schedule, "2024-06-25 12:00", true, "2024-06-25 14:00")
+}
+
+func TestOpeningStatus_AlwaysOpen(t *testing.T) {
+	var hours []db.OpeningHour
+	for weekday := int16(1); weekday <= 7; weekday++ {
+		hours = append(hours, hoursRow(1, weekday, "00:00", "24:00"))
+	}
+	schedule := buildOpeningSchedules(hours, nil)[1]
+
+	assertStatus(t, schedule, "2024-06-03 12:00", true, "")
+}
+
+func TestOpeningStatus_DaylightSavingChange(t *testing.T) {
+	// Clocks go forward at 03:00 on Sunday 2024-03-31, the shop still opens at 10:00 local time
+	schedule := buildOpeningSchedules([]db.OpeningHour{
+		hoursRow(1, 7, "10:00", "16:00"),
+	}, nil)[1]
+
+	isOpen, next := schedule.Status(time.Date(2024, 3, 31, 6, 0, 0, 0, time.UTC))
+	if isOpen || next == nil || next.UTC() != time.Date(2024, 3, 31, 7, 0, 0, 0, time.UTC) {
+		t.Fatalf("expected to open at 07:00 UTC, got open=%v next=%v", isOpen, next)
+	}
+}
+
+func TestListSpots_OpenAtFilter(t *testing.T) {
+	// Still the previous day in UTC, the filter needs the Vilnius date
+	at := vilniusTime(t, "2024-06-03 00:30")
+	checkOpenFilter := func(filterOpen bool, date pgtype.Date, clock pgtype.Time) {
+		if !filterOpen || date.Time.Format(dateLayout) != "2024-06-03" || clock != minutesToTime(30) {
+			t.Fatalf("expected open at 2024-06-03 00:30, got %v %v %v", filterOpen, date.Time, clock)
+		}
+	}
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+			checkOpenFilter(arg.FilterOpen, arg.OpenDate, arg.OpenTime)
+			return []db.ListSpotsByIDRow{{ID: 1, Category: "Restoranai", HasOpeningHours: true}}, nil
+		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			checkOpenFilter(arg.FilterOpen, arg.OpenDate, arg.OpenTime)
+			return 1, nil
+		},
+		GetOpeningHoursBySpotIDsFunc: func(ctx context.Context, spotIds []int64) ([]db.OpeningHour, error) {
+			return []db.OpeningHour{hoursRow(1, 1, "09:00", "18:00")}, nil
+		},
+		GetOpeningHoursExceptionsBySpotIDsFunc: func(ctx context.Context, arg db.GetOpeningHoursExceptionsBySpotIDsParams) ([]db.OpeningHoursException, error) {
+			return nil, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	page, err := svc.ListSpots(context.Background(), SpotListQuery{OpenAt: &at})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].OpeningStatus == nil {
+		t.Fatalf("expected an opening status on the card, got %+v", page.Items)
+	}
+}
+
+// openingHoursOfSpot1 mocks the opening hours queries, only spot 1 has a schedule
+func openingHoursOfSpot1(t *testing.T, mock *mocks.MockSpotQueries) {
+	mock.GetOpeningHoursBySpotIDsFunc = func(ctx context.Context, spotIds []int64) ([]db.OpeningHour, error) {
+		if len(spotIds) != 1 || spotIds[0] != 1 {
+			t.Fatalf("expected only the spot with opening hours, got %v", spotIds)
+		}
+		return []db.OpeningHour{hoursRow(1, 1, "09:00", "18:00")}, nil
+	}
+	mock.GetOpeningHoursExceptionsBySpotIDsFunc = func(ctx context.Context, arg db.GetOpeningHoursExceptionsBySpotIDsParams) ([]db.OpeningHoursException, error) {
+		return nil, nil
+	}
+}
+
+func TestSearchSpots_OpeningStatus(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		SearchSpotsFunc: func(ctx context.Context, arg db.SearchSpotsParams) ([]db.SearchSpotsRow, error) {
+			return []db.SearchSpotsRow{
+				{ID: 1, Name: "Kavinė", Category: "Restoranai", HasOpeningHours: true},
+				{ID: 2, Name: "Parkas", Category: "Gamta"},
+			}, nil
+		},
+	}
+	openingHoursOfSpot1(t, mock)
+
+	svc := NewSpotService(mock)
+
+	results, err := svc.SearchSpots(context.Background(), "kavine", false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].OpeningStatus == nil || results[1].OpeningStatus != nil {
+		t.Fatalf("expected an opening status on the first result only, got %+v", results)
+	}
+}
+
+func TestGetSpotsInBBox_OpeningStatus(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		GetSpotsInBBoxFunc: func(ctx context.Context, arg db.GetSpotsInBBoxParams) ([]db.GetSpotsInBBoxRow, error) {
+			return []db.GetSpotsInBBoxRow{
+				{ID: 1, Name: "Kavinė", Category: "Restoranai", HasOpeningHours: true},
+				{ID: 2, Name: "Parkas", Category: "Gamta"},
+			}, nil
+		},
+	}
+	openingHoursOfSpot1(t, mock)
+
+	svc := NewSpotService(mock)
+
+	result, err := svc.GetSpotsInBBox(context.Background(), BBox{MinLat: 54.7, MinLng: 25.2, MaxLat: 54.71, MaxLng: 25.21}, MinSpotZoom, false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Spots[0].OpeningStatus == nil || result.Spots[1].OpeningStatus != nil {
+		t.Fatalf("expected an opening status on the first spot only, got %+v", result.Spots)
+	}
+}
+
+func TestSetOpeningHours_InvalidInput(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	inputs := []OpeningHoursInput{
+		{Weekly: []OpeningShiftInput{{Weekday: 0, Opens: "09:00", Closes: "18:00"}}},
+		{Weekly: []OpeningShiftInput{{Weekday: 1, Opens: "9:00", Closes: "18:00"}}},
+		{Weekly: []OpeningShiftInput{{Weekday: 1, Opens: "24:00", Closes: "02:00"}}},
+		{Weekly: []OpeningShiftInput{{Weekday: 1, Opens: "09:00", Closes: "09:00"}}},
+		{Exceptions: []OpeningHoursExceptionInput{{Date: "2024-13-01"}}},
+		{Exceptions: []OpeningHoursExceptionInput{{Date: "2024-12-24", Opens: "10:00"}}},
+	}
+	for _, in := range inputs {
+		_, err := svc.SetOpeningHours(context.Background(), 1, in)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected validation error for %+v, got %v", in, err)
+		}
+	}
+}
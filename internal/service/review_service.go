@@ -0,0 +1,190 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+
+	maxReviewCommentLength = 1000
+)
+
+// ReviewInput holds the user editable fields of a review
+type ReviewInput struct {
+	Rating  int
+	Comment string
+}
+
+func (in ReviewInput) validate() error {
+	if in.Rating < MinRating || in.Rating > MaxRating {
+		return newValidationError("rating must be between %d and %d, got: %d", MinRating, MaxRating, in.Rating)
+	}
+	if utf8.RuneCountInString(in.Comment) > maxReviewCommentLength {
+		return newValidationError("comment cannot be longer than %d characters", maxReviewCommentLength)
+	}
+	return nil
+}
+
+type ReviewService struct {
+	queries interfaces.ReviewQueries
+}
+
+func NewReviewService(queries interfaces.ReviewQueries) *ReviewService {
+	return &ReviewService{queries: queries}
+}
+
+// GetSpotReviews returns the reviews of a spot, newest first.
+// Reviews of secret spots are only visible when includeSecret is set.
+func (s *ReviewService) GetSpotReviews(ctx context.Context, spotID int64, includeSecret bool) (*dto.SpotReviewsDTO, error) {
+	if err := s.checkSpotVisible(ctx, spotID, includeSecret); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetReviewsBySpotID(ctx, spotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	rating, err := s.queries.GetSpotRating(ctx, spotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spot rating: %w", err)
+	}
+
+	reviews := make([]dto.ReviewDTO, len(rows))
+	for i, row := range rows {
+		reviews[i] = *toReviewDTO(row)
+	}
+	return &dto.SpotReviewsDTO{
+		SpotID:        spotID,
+		AverageRating: rating.AverageRating,
+		ReviewCount:   rating.ReviewCount,
+		Reviews:       reviews,
+	}, nil
+}
+
+// CreateReview adds the user's review of a spot, every user can review a spot once
+func (s *ReviewService) CreateReview(ctx context.Context, spotID, userID int64, in ReviewInput) (*dto.ReviewDTO, error) {
+	in.Comment = strings.TrimSpace(in.Comment)
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	// Only logged in users write reviews, and they can see secret spots
+	if err := s.checkSpotVisible(ctx, spotID, true); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.InsertReview(ctx, db.InsertReviewParams{
+		SpotID:  spotID,
+		UserID:  userID,
+		Rating:  int16(in.Rating),
+		Comment: in.Comment,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReviewExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert review: %w", err)
+	}
+	return toReviewDTO(row), nil
+}
+
+// UpdateReview changes a review, only its author may do that
+func (s *ReviewService) UpdateReview(ctx context.Context, spotID, reviewID, userID int64, in ReviewInput) (*dto.ReviewDTO, error) {
+	in.Comment = strings.TrimSpace(in.Comment)
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	review, err := s.getSpotReview(ctx, spotID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	row, err := s.queries.UpdateReview(ctx, db.UpdateReviewParams{
+		ID:      reviewID,
+		Rating:  int16(in.Rating),
+		Comment: in.Comment,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+	return toReviewDTO(row), nil
+}
+
+// DeleteReview removes a review. Authors can remove their own reviews, admins any review.
+func (s *ReviewService) DeleteReview(ctx context.Context, spotID, reviewID, userID int64, isAdmin bool) error {
+	review, err := s.getSpotReview(ctx, spotID, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID != userID && !isAdmin {
+		return ErrForbidden
+	}
+
+	if _, err := s.queries.DeleteReview(ctx, reviewID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReviewNotFound
+		}
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+	return nil
+}
+
+func (s *ReviewService) checkSpotVisible(ctx context.Context, spotID int64, includeSecret bool) error {
+	spot, err := s.queries.GetSpotByID(ctx, spotID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrSpotNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get spot: %w", err)
+	}
+	// Anonymous users must not learn that a secret spot exists
+	visible, err := spotVisible(ctx, s.queries, spot, includeSecret)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrSpotNotFound
+	}
+	return nil
+}
+
+// getSpotReview loads a review and makes sure it belongs to the spot from the URL
+func (s *ReviewService) getSpotReview(ctx context.Context, spotID, reviewID int64) (db.Review, error) {
+	review, err := s.queries.GetReviewByID(ctx, reviewID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && review.SpotID != spotID) {
+		return db.Review{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return db.Review{}, fmt.Errorf("failed to get review: %w", err)
+	}
+	return review, nil
+}
+
+func toReviewDTO(row db.Review) *dto.ReviewDTO {
+	return &dto.ReviewDTO{
+		ID:        row.ID,
+		SpotID:    row.SpotID,
+		UserID:    row.UserID,
+		Rating:    int(row.Rating),
+		Comment:   row.Comment,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestCreateReview_Success(t *testing.T) {
+	mock := &mocks.MockReviewQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Gamta"}, nil
+		},
+		InsertReviewFunc: func(ctx context.Context, arg db.InsertReviewParams) (db.Review, error) {
+			if arg.Comment != "Labai gražu" {
+				t.Fatalf("expected trimmed comment, got %q", arg.Comment)
+			}
+			return db.Review{ID: 1, SpotID: arg.SpotID, UserID: arg.UserID, Rating: arg.Rating, Comment: arg.Comment}, nil
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	review, err := svc.CreateReview(context.Background(), 3, 42, ReviewInput{Rating: 5, Comment: "  Labai gražu "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.SpotID != 3 || review.UserID != 42 || review.Rating != 5 {
+		t.Fatalf("unexpected review: %+v", review)
+	}
+}
+
+func TestCreateReview_InvalidRating(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewReviewService(&mocks.MockReviewQueries{})
+
+	_, err := svc.CreateReview(context.Background(), 3, 42, ReviewInput{Rating: 6})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestCreateReview_AlreadyReviewed(t *testing.T) {
+	mock := &mocks.MockReviewQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id}, nil
+		},
+		InsertReviewFunc: func(ctx context.Context, arg db.InsertReviewParams) (db.Review, error) {
+			return db.Review{}, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	_, err := svc.CreateReview(context.Background(), 3, 42, ReviewInput{Rating: 4})
+	if !errors.Is(err, ErrReviewExists) {
+		t.Fatalf("expected ErrReviewExists, got %v", err)
+	}
+}
+
+func TestUpdateReview_NotAuthor(t *testing.T) {
+	mock := &mocks.MockReviewQueries{
+		GetReviewByIDFunc: func(ctx context.Context, id int64) (db.Review, error) {
+			return db.Review{ID: id, SpotID: 3, UserID: 7}, nil
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	_, err := svc.UpdateReview(context.Background(), 3, 1, 42, ReviewInput{Rating: 1})
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestDeleteReview_AdminCanDeleteAny(t *testing.T) {
+	deleted := false
+	mock := &mocks.MockReviewQueries{
+		GetReviewByIDFunc: func(ctx context.Context, id int64) (db.Review, error) {
+			return db.Review{ID: id, SpotID: 3, UserID: 7}, nil
+		},
+		DeleteReviewFunc: func(ctx context.Context, id int64) (db.Review, error) {
+			deleted = true
+			return db.Review{ID: id}, nil
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	if err := svc.DeleteReview(context.Background(), 3, 1, 42, false); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for another user's review, got %v", err)
+	}
+	if deleted {
+		t.Fatal("review SHOULD NOT have been deleted")
+	}
+
+	if err := svc.DeleteReview(context.Background(), 3, 1, 42, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !deleted {
+		t.Fatal("admin should be able to delete any review")
+	}
+}
+
+func TestDeleteReview_WrongSpot(t *testing.T) {
+	mock := &mocks.MockReviewQueries{
+		GetReviewByIDFunc: func(ctx context.Context, id int64) (db.Review, error) {
+			return db.Review{ID: id, SpotID: 9, UserID: 42}, nil
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	err := svc.DeleteReview(context.Background(), 3, 1, 42, false)
+	if !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected ErrReviewNotFound, got %v", err)
+	}
+}
+
+func TestGetSpotReviews_SecretSpotHidden(t *testing.T) {
+	mock := &mocks.MockReviewQueries{
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Slaptos_vietos"}, nil
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	_, err := svc.GetSpotReviews(context.Background(), 3, false)
+	if !errors.Is(err, ErrSpotNotFound) {
+		t.Fatalf("expected ErrSpotNotFound for anonymous request, got %v", err)
+	}
+}
+
+func TestGetSpotReviews_Success(t *testing.T) {
+	mock := &mocks.MockReviewQueries{
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Gamta"}, nil
+		},
+		GetReviewsBySpotIDFunc: func(ctx context.Context, spotID int64) ([]db.Review, error) {
+			return []db.Review{{ID: 2, SpotID: spotID, Rating: 4}, {ID: 1, SpotID: spotID, Rating: 5}}, nil
+		},
+		GetSpotRatingFunc: func(ctx context.Context, spotID int64) (db.GetSpotRatingRow, error) {
+			return db.GetSpotRatingRow{ReviewCount: 2, AverageRating: 4.5}, nil
+		},
+	}
+
+	svc := NewReviewService(mock)
+
+	reviews, err := svc.GetSpotReviews(context.Background(), 3, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reviews.ReviewCount != 2 || reviews.AverageRating != 4.5 || len(reviews.Reviews) != 2 {
+		t.Fatalf("unexpected reviews: %+v", reviews)
+	}
+}
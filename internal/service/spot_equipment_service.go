@@ -0,0 +1,113 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const maxEquipmentQuantity = 100
+
+// EquipmentInput sets one kind of equipment, an empty condition means good
+type EquipmentInput struct {
+	Type      db.EquipmentType
+	Quantity  int
+	Condition db.EquipmentCondition
+}
+
+// GetSpotEquipment lists the inventory of a spot, secret spots only for logged in users
+func (s *SpotService) GetSpotEquipment(ctx context.Context, spotID int64, includeSecret bool) ([]dto.EquipmentDTO, error) {
+	if _, err := s.getVisibleSpot(ctx, spotID, includeSecret); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetSpotEquipment(ctx, spotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+
+	dtos := make([]dto.EquipmentDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = *toEquipmentDTO(row)
+	}
+	return dtos, nil
+}
+
+// SetSpotEquipment adds a kind of equipment to a spot in a category with equipment, such as
+// outdoor gyms, or replaces its quantity and condition
+func (s *SpotService) SetSpotEquipment(ctx context.Context, spotID int64, in EquipmentInput) (*dto.EquipmentDTO, error) {
+	if in.Condition == "" {
+		in.Condition = db.EquipmentConditionGood
+	}
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	spot, err := s.queries.GetSpotByID(ctx, spotID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSpotNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spot: %w", err)
+	}
+	category, err := s.queries.GetCategoryBySlug(ctx, spot.Category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if !category.HasEquipment {
+		return nil, newValidationError("equipment can only be listed for spots in categories with equipment")
+	}
+
+	row, err := s.queries.UpsertSpotEquipment(ctx, db.UpsertSpotEquipmentParams{
+		SpotID:    spotID,
+		Type:      in.Type,
+		Quantity:  int32(in.Quantity),
+		Condition: in.Condition,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save equipment: %w", err)
+	}
+	return toEquipmentDTO(row), nil
+}
+
+// RemoveSpotEquipment deletes a kind of equipment from a spot
+func (s *SpotService) RemoveSpotEquipment(ctx context.Context, spotID int64, equipmentType db.EquipmentType) error {
+	// The type comes from the URL, an unknown one would fail the enum cast in the query
+	if !equipmentType.Valid() {
+		return newValidationError("invalid equipment type: %v", equipmentType)
+	}
+	_, err := s.queries.DeleteSpotEquipment(ctx, db.DeleteSpotEquipmentParams{SpotID: spotID, Type: equipmentType})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEquipmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete equipment: %w", err)
+	}
+	return nil
+}
+
+func (in EquipmentInput) validate() error {
+	if !in.Type.Valid() {
+		return newValidationError("invalid equipment type: %v", in.Type)
+	}
+	if !in.Condition.Valid() {
+		return newValidationError("invalid equipment condition: %v", in.Condition)
+	}
+	if in.Quantity < 1 || in.Quantity > maxEquipmentQuantity {
+		return newValidationError("quantity must be between 1 and %d, got: %d", maxEquipmentQuantity, in.Quantity)
+	}
+	return nil
+}
+
+func toEquipmentDTO(row db.SpotEquipment) *dto.EquipmentDTO {
+	return &dto.EquipmentDTO{
+		Type:      string(row.Type),
+		Quantity:  row.Quantity,
+		Condition: string(row.Condition),
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestSetSpotEquipment_DefaultsToGoodCondition(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Lauko_treniruokliai"}, nil
+		},
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+		UpsertSpotEquipmentFunc: func(ctx context.Context, arg db.UpsertSpotEquipmentParams) (db.SpotEquipment, error) {
+			if arg.Condition != db.EquipmentConditionGood {
+				t.Fatalf("expected good condition, got %s", arg.Condition)
+			}
+			return db.SpotEquipment{SpotID: arg.SpotID, Type: arg.Type, Quantity: arg.Quantity, Condition: arg.Condition}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	equipment, err := svc.SetSpotEquipment(context.Background(), 3, EquipmentInput{Type: db.EquipmentTypePullUpBar, Quantity: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if equipment.Type != "pull_up_bar" || equipment.Quantity != 2 {
+		t.Fatalf("unexpected equipment: %+v", equipment)
+	}
+}
+
+func TestSetSpotEquipment_OnlyCategoriesWithEquipment(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Restoranai"}, nil
+		},
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+	}
+
+	svc := NewSpotService(mock)
+
+	_, err := svc.SetSpotEquipment(context.Background(), 3, EquipmentInput{Type: db.EquipmentTypeLegPress, Quantity: 1})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestSetSpotEquipment_NewCategoryWithEquipment(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Sporto_aikstynai"}, nil
+		},
+		GetCategoryBySlugFunc: func(ctx context.Context, slug string) (db.Category, error) {
+			return db.Category{Slug: slug, HasEquipment: true}, nil
+		},
+		UpsertSpotEquipmentFunc: func(ctx context.Context, arg db.UpsertSpotEquipmentParams) (db.SpotEquipment, error) {
+			return db.SpotEquipment{SpotID: arg.SpotID, Type: arg.Type, Quantity: arg.Quantity, Condition: arg.Condition}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	if _, err := svc.SetSpotEquipment(context.Background(), 3, EquipmentInput{Type: db.EquipmentTypeRings, Quantity: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSetSpotEquipment_InvalidInput(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	inputs := []EquipmentInput{
+		{Type: "trampoline", Quantity: 1},
+		{Type: db.EquipmentTypeRings, Quantity: 0},
+		{Type: db.EquipmentTypeRings, Quantity: 1, Condition: "new"},
+	}
+	for _, in := range inputs {
+		_, err := svc.SetSpotEquipment(context.Background(), 3, in)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected validation error for %+v, got %v", in, err)
+		}
+	}
+}
+
+func TestRemoveSpotEquipment_NotFound(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		DeleteSpotEquipmentFunc: func(ctx context.Context, arg db.DeleteSpotEquipmentParams) (db.SpotEquipment, error) {
+			return db.SpotEquipment{}, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	err := svc.RemoveSpotEquipment(context.Background(), 3, db.EquipmentTypeDipStation)
+	if !errors.Is(err, ErrEquipmentNotFound) {
+		t.Fatalf("expected ErrEquipmentNotFound, got %v", err)
+	}
+}
+
+func TestRemoveSpotEquipment_InvalidType(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	err := svc.RemoveSpotEquipment(context.Background(), 3, "trampoline")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestListSpots_EquipmentFilter(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+			if len(arg.Equipment) != 2 || arg.Equipment[0] != "pull_up_bar" || arg.Equipment[1] != "parallel_bars" {
+				t.Fatalf("expected deduplicated equipment, got %v", arg.Equipment)
+			}
+			return nil, nil
+		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			if len(arg.Equipment) != 2 {
+				t.Fatalf("expected the count to use the equipment filter too, got %v", arg.Equipment)
+			}
+			return 0, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	_, err := svc.ListSpots(context.Background(), SpotListQuery{
+		Equipment: []db.EquipmentType{db.EquipmentTypePullUpBar, db.EquipmentTypeParallelBars, db.EquipmentTypePullUpBar},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = svc.ListSpots(context.Background(), SpotListQuery{Equipment: []db.EquipmentType{"trampoline"}})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"context"
+	"fmt"
+)
+
+// SpotExportQuery selects the spots of a map file export, no categories means all of them
+// and a nil BBox means everywhere
+type SpotExportQuery struct {
+	Categories    []string
+	BBox          *BBox
+	IncludeSecret bool
+}
+
+// ExportSpots calls fn for every matching spot as it is read from the database, so an
+// export never holds the whole table in memory. Invalid queries fail before the first call.
+// Spots arrive grouped by category, in the display order of the categories.
+func (s *SpotService) ExportSpots(ctx context.Context, q SpotExportQuery, fn func(dto.SpotExportDTO) error) error {
+	params := db.ExportSpotsParams{IncludeSecret: q.IncludeSecret}
+	if q.BBox != nil {
+		if err := q.BBox.validate(); err != nil {
+			return err
+		}
+		params.FilterBbox = true
+		params.MinLat = q.BBox.MinLat
+		params.MaxLat = q.BBox.MaxLat
+		params.MinLng = q.BBox.MinLng
+		params.MaxLng = q.BBox.MaxLng
+	}
+
+	categories, err := s.checkListCategories(ctx, q.Categories, q.IncludeSecret)
+	if err != nil {
+		return err
+	}
+	params.Categories = categories
+	names, err := categoryNames(ctx, s.queries)
+	if err != nil {
+		return err
+	}
+
+	err = s.queries.ExportSpotsEach(ctx, params, func(row db.ExportSpotsRow) error {
+		return fn(dto.SpotExportDTO{
+			ID:           row.ID,
+			Name:         row.Name,
+			Category:     row.Category,
+			CategoryName: names[row.Category],
+			Description:  row.Description,
+			Address:      row.Address,
+			Latitude:     row.Latitude,
+			Longitude:    row.Longitude,
+			ImageURL:     row.ImageUrl,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export spots: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExportSpots_StreamsRowsWithCategoryNames(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ExportSpotsEachFunc: func(ctx context.Context, arg db.ExportSpotsParams, fn func(db.ExportSpotsRow) error) error {
+			if arg.IncludeSecret || len(arg.Categories) != 0 {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			for _, row := range []db.ExportSpotsRow{
+				{ID: 1, Name: "Parkas", Category: "Gamta", Latitude: 54.7, Longitude: 25.2, ImageUrl: "/uploads/1.jpg"},
+				{ID: 2, Name: "Parduotuvė", Category: "Parduotuves"},
+			} {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	var spots []dto.SpotExportDTO
+	err := svc.ExportSpots(context.Background(), SpotExportQuery{}, func(spot dto.SpotExportDTO) error {
+		spots = append(spots, spot)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spots) != 2 || spots[0].ImageURL != "/uploads/1.jpg" || spots[1].CategoryName != "Parduotuvės" {
+		t.Fatalf("unexpected spots: %+v", spots)
+	}
+}
+
+func TestExportSpots_SecretCategoryRequiresAuth(t *testing.T) {
+	// ExportSpotsEachFunc is not set, so reaching the export query would panic
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+	}
+
+	svc := NewSpotService(mock)
+
+	err := svc.ExportSpots(context.Background(), SpotExportQuery{Categories: []string{"Slaptos_vietos"}}, func(dto.SpotExportDTO) error {
+		t.Fatalf("no spot should be written")
+		return nil
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestExportSpots_WriterErrorStopsExport(t *testing.T) {
+	writeErr := errors.New("client went away")
+	calls := 0
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ExportSpotsEachFunc: func(ctx context.Context, arg db.ExportSpotsParams, fn func(db.ExportSpotsRow) error) error {
+			for _, row := range []db.ExportSpotsRow{{ID: 1, Category: "Gamta"}, {ID: 2, Category: "Gamta"}} {
+				calls++
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	err := svc.ExportSpots(context.Background(), SpotExportQuery{}, func(dto.SpotExportDTO) error {
+		return writeErr
+	})
+	if !errors.Is(err, writeErr) || calls != 1 {
+		t.Fatalf("expected the export to stop at the first error, got %v after %d rows", err, calls)
+	}
+}
+
+func TestExportSpots_BBoxFilter(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ExportSpotsEachFunc: func(ctx context.Context, arg db.ExportSpotsParams, fn func(db.ExportSpotsRow) error) error {
+			if !arg.FilterBbox || arg.MinLat != 54.6 || arg.MaxLng != 25.3 || len(arg.Categories) != 1 || arg.Categories[0] != "Gamta" {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			return nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	err := svc.ExportSpots(context.Background(), SpotExportQuery{
+		Categories: []string{"Gamta"},
+		BBox:       &BBox{MinLat: 54.6, MinLng: 25.1, MaxLat: 54.8, MaxLng: 25.3},
+	}, func(dto.SpotExportDTO) error { return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExportSpots_InvalidBBox(t *testing.T) {
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	err := svc.ExportSpots(context.Background(), SpotExportQuery{
+		BBox: &BBox{MinLat: 55, MinLng: 25.1, MaxLat: 54, MaxLng: 25.3},
+	}, func(dto.SpotExportDTO) error { return nil })
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
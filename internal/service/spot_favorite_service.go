@@ -0,0 +1,95 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"context"
+	"fmt"
+	"time"
+)
+
+// AddFavorite saves a spot for the user, saving it twice is not an error. Only logged
+// in users have favorites, so secret spots can always be saved.
+func (s *SpotService) AddFavorite(ctx context.Context, userID, spotID int64) error {
+	if _, err := s.getVisibleSpot(ctx, spotID, true); err != nil {
+		return err
+	}
+
+	if err := s.queries.AddFavorite(ctx, db.AddFavoriteParams{UserID: userID, SpotID: spotID}); err != nil {
+		return fmt.Errorf("failed to add favorite: %w", err)
+	}
+	return nil
+}
+
+// RemoveFavorite forgets a saved spot, removing a spot that was never saved is not an error
+func (s *SpotService) RemoveFavorite(ctx context.Context, userID, spotID int64) error {
+	if err := s.queries.RemoveFavorite(ctx, db.RemoveFavoriteParams{UserID: userID, SpotID: spotID}); err != nil {
+		return fmt.Errorf("failed to remove favorite: %w", err)
+	}
+	return nil
+}
+
+// GetFavoriteSpots returns the user's saved spots, most recently saved first. Secret
+// spots are included, the owner of the favorites is always logged in.
+func (s *SpotService) GetFavoriteSpots(ctx context.Context, userID int64) ([]dto.SpotCardDTO, error) {
+	rows, err := s.queries.GetFavoriteSpots(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorite spots: %w", err)
+	}
+
+	isFavorite := true
+	var withHours []int64
+	dtos := make([]dto.SpotCardDTO, len(rows))
+	for i, row := range rows {
+		if row.HasOpeningHours {
+			withHours = append(withHours, row.ID)
+		}
+		dtos[i] = dto.SpotCardDTO{
+			ID:            row.ID,
+			Name:          row.Name,
+			Category:      row.Category,
+			Address:       row.Address,
+			ImageURL:      row.ImageUrl,
+			AverageRating: row.AverageRating,
+			ReviewCount:   row.ReviewCount,
+			Latitude:      row.Latitude,
+			Longitude:     row.Longitude,
+			IsFavorite:    &isFavorite,
+		}
+	}
+
+	if err := s.nameCategories(ctx, dtos); err != nil {
+		return nil, err
+	}
+	if err := s.markOpeningStatus(ctx, dtos, withHours, time.Now()); err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// markFavorites sets IsFavorite on every card, anonymous requests (userID 0) are left untouched
+func (s *SpotService) markFavorites(ctx context.Context, userID int64, cards []dto.SpotCardDTO) error {
+	if userID == 0 || len(cards) == 0 {
+		return nil
+	}
+
+	spotIDs := make([]int64, len(cards))
+	for i, card := range cards {
+		spotIDs[i] = card.ID
+	}
+
+	favoriteIDs, err := s.queries.GetFavoriteSpotIDs(ctx, db.GetFavoriteSpotIDsParams{UserID: userID, SpotIds: spotIDs})
+	if err != nil {
+		return fmt.Errorf("failed to get favorites: %w", err)
+	}
+	favorites := make(map[int64]bool, len(favoriteIDs))
+	for _, id := range favoriteIDs {
+		favorites[id] = true
+	}
+
+	for i := range cards {
+		isFavorite := favorites[cards[i].ID]
+		cards[i].IsFavorite = &isFavorite
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	maxShiftsPerDay      = 4
+	maxHoursExceptions   = 100
+	maxExceptionNoteSize = 200
+	// exceptionListDays is how far ahead exception dates are shown with the schedule
+	exceptionListDays = 366
+)
+
+// OpeningHoursInput replaces the whole schedule of a spot, times are HH:MM in Europe/Vilnius
+type OpeningHoursInput struct {
+	Weekly     []OpeningShiftInput
+	Exceptions []OpeningHoursExceptionInput
+}
+
+// OpeningShiftInput is one shift, weekday 1 is Monday. Closes <= Opens ends on the next day.
+type OpeningShiftInput struct {
+	Weekday int
+	Opens   string
+	Closes  string
+}
+
+// OpeningHoursExceptionInput replaces the shifts of one date (YYYY-MM-DD).
+// Without times the spot is closed that day, several rows on one date are split shifts.
+type OpeningHoursExceptionInput struct {
+	Date   string
+	Opens  string
+	Closes string
+	Note   string
+}
+
+// GetOpeningHours returns the weekly schedule, upcoming exception dates and the current status
+func (s *SpotService) GetOpeningHours(ctx context.Context, spotID int64, includeSecret bool) (*dto.OpeningHoursDTO, error) {
+	if _, err := s.getVisibleSpot(ctx, spotID, includeSecret); err != nil {
+		return nil, err
+	}
+	return s.getOpeningHours(ctx, s.queries, spotID, time.Now())
+}
+
+// SetOpeningHours replaces the schedule of a spot, an empty input removes it
+func (s *SpotService) SetOpeningHours(ctx context.Context, spotID int64, in OpeningHoursInput) (*dto.OpeningHoursDTO, error) {
+	hours, exceptions, err := in.toParams(spotID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *dto.OpeningHoursDTO
+	err = s.queries.ExecTx(ctx, func(q interfaces.SpotQueries) error {
+		if _, err := q.GetSpotByID(ctx, spotID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrSpotNotFound
+			}
+			return fmt.Errorf("failed to get spot: %w", err)
+		}
+
+		if err := q.DeleteOpeningHours(ctx, spotID); err != nil {
+			return fmt.Errorf("failed to delete opening hours: %w", err)
+		}
+		if err := q.DeleteOpeningHoursExceptions(ctx, spotID); err != nil {
+			return fmt.Errorf("failed to delete opening hours exceptions: %w", err)
+		}
+		for _, params := range hours {
+			if err := q.InsertOpeningHours(ctx, params); err != nil {
+				return fmt.Errorf("failed to insert opening hours: %w", err)
+			}
+		}
+		for _, params := range exceptions {
+			if err := q.InsertOpeningHoursException(ctx, params); err != nil {
+				return fmt.Errorf("failed to insert opening hours exception: %w", err)
+			}
+		}
+
+		var err error
+		result, err = s.getOpeningHours(ctx, q, spotID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *SpotService) getOpeningHours(ctx context.Context, q interfaces.SpotQueries, spotID int64, at time.Time) (*dto.OpeningHoursDTO, error) {
+	hours, err := q.GetOpeningHoursBySpotIDs(ctx, []int64{spotID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening hours: %w", err)
+	}
+	from, _ := exceptionDateRange(at)
+	exceptions, err := q.GetOpeningHoursExceptionsBySpotIDs(ctx, db.GetOpeningHoursExceptionsBySpotIDsParams{
+		SpotIds:  []int64{spotID},
+		FromDate: from,
+		ToDate:   pgtype.Date{Time: from.Time.AddDate(0, 0, exceptionListDays), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening hours exceptions: %w", err)
+	}
+
+	result := &dto.OpeningHoursDTO{
+		SpotID:     spotID,
+		Timezone:   OpeningHoursTimezone,
+		Weekly:     []dto.OpeningShiftDTO{},
+		Exceptions: []dto.OpeningHoursExceptionDTO{},
+	}
+	for _, row := range hours {
+		result.Weekly = append(result.Weekly, dto.OpeningShiftDTO{
+			Weekday: int(row.Weekday),
+			Opens:   formatClock(timeToMinutes(row.Opens)),
+			Closes:  formatClock(timeToMinutes(row.Closes)),
+		})
+	}
+
+	// Yesterday was only loaded for its overnight shifts
+	today := at.In(openingHoursLocation).Format(dateLayout)
+	for _, row := range exceptions {
+		date := row.Date.Time.Format(dateLayout)
+		if date < today {
+			continue
+		}
+		exception := dto.OpeningHoursExceptionDTO{Date: date, Note: row.Note}
+		if row.Opens.Valid && row.Closes.Valid {
+			exception.Opens = formatClock(timeToMinutes(row.Opens))
+			exception.Closes = formatClock(timeToMinutes(row.Closes))
+		}
+		result.Exceptions = append(result.Exceptions, exception)
+	}
+
+	if schedule, ok := buildOpeningSchedules(hours, exceptions)[spotID]; ok {
+		result.Status = schedule.statusDTO(at)
+	}
+	return result, nil
+}
+
+// markOpeningStatus sets OpeningStatus on the cards of the given spots, which are the
+// ones the card query reported to have opening hours
+func (s *SpotService) markOpeningStatus(ctx context.Context, cards []dto.SpotCardDTO, spotIDs []int64, at time.Time) error {
+	if len(spotIDs) == 0 {
+		return nil
+	}
+
+	hours, err := s.queries.GetOpeningHoursBySpotIDs(ctx, spotIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get opening hours: %w", err)
+	}
+	from, to := exceptionDateRange(at)
+	exceptions, err := s.queries.GetOpeningHoursExceptionsBySpotIDs(ctx, db.GetOpeningHoursExceptionsBySpotIDsParams{
+		SpotIds:  spotIDs,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get opening hours exceptions: %w", err)
+	}
+
+	schedules := buildOpeningSchedules(hours, exceptions)
+	for i := range cards {
+		if schedule, ok := schedules[cards[i].ID]; ok {
+			cards[i].OpeningStatus = schedule.statusDTO(at)
+		}
+	}
+	return nil
+}
+
+func (in OpeningHoursInput) toParams(spotID int64) ([]db.InsertOpeningHoursParams, []db.InsertOpeningHoursExceptionParams, error) {
+	var shiftsPerDay [8]int
+	hours := make([]db.InsertOpeningHoursParams, len(in.Weekly))
+	for i, shift := range in.Weekly {
+		if shift.Weekday < 1 || shift.Weekday > 7 {
+			return nil, nil, newValidationError("weekday must be between 1 (Monday) and 7 (Sunday), got: %d", shift.Weekday)
+		}
+		shiftsPerDay[shift.Weekday]++
+		if shiftsPerDay[shift.Weekday] > maxShiftsPerDay {
+			return nil, nil, newValidationError("a day can have at most %d shifts", maxShiftsPerDay)
+		}
+		opens, closes, err := parseShift(shift.Opens, shift.Closes)
+		if err != nil {
+			return nil, nil, err
+		}
+		hours[i] = db.InsertOpeningHoursParams{
+			SpotID:  spotID,
+			Weekday: int16(shift.Weekday),
+			Opens:   minutesToTime(opens),
+			Closes:  minutesToTime(closes),
+		}
+	}
+
+	if len(in.Exceptions) > maxHoursExceptions {
+		return nil, nil, newValidationError("at most %d exception dates are allowed", maxHoursExceptions)
+	}
+	exceptions := make([]db.InsertOpeningHoursExceptionParams, len(in.Exceptions))
+	for i, exception := range in.Exceptions {
+		date, err := time.Parse(dateLayout, exception.Date)
+		if err != nil {
+			return nil, nil, newValidationError("invalid date %q, expected YYYY-MM-DD", exception.Date)
+		}
+		if utf8.RuneCountInString(exception.Note) > maxExceptionNoteSize {
+			return nil, nil, newValidationError("note cannot be longer than %d characters", maxExceptionNoteSize)
+		}
+
+		params := db.InsertOpeningHoursExceptionParams{
+			SpotID: spotID,
+			Date:   pgtype.Date{Time: date, Valid: true},
+			Note:   exception.Note,
+		}
+		if exception.Opens != "" || exception.Closes != "" {
+			opens, closes, err := parseShift(exception.Opens, exception.Closes)
+			if err != nil {
+				return nil, nil, err
+			}
+			params.Opens = minutesToTime(opens)
+			params.Closes = minutesToTime(closes)
+		}
+		exceptions[i] = params
+	}
+	return hours, exceptions, nil
+}
+
+func parseShift(opensValue, closesValue string) (int, int, error) {
+	opens, err := parseClock(opensValue, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	closes, err := parseClock(closesValue, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	if opens == closes {
+		return 0, 0, newValidationError("opening and closing time cannot be the same, use 00:00-24:00 for a full day")
+	}
+	return opens, closes, nil
+}
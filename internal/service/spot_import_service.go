@@ -0,0 +1,173 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/i18n"
+	"PilaiteProject/internal/importer"
+	"PilaiteProject/internal/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// MaxImportUploadSize limits import files sent over HTTP
+	MaxImportUploadSize = 10 << 20
+	// MaxImportRows limits one import, larger datasets can be split into several files
+	MaxImportRows = 5000
+	// duplicateDistance is how close in degrees (about 50 meters) a spot with the same
+	// name has to be to count as a duplicate, GetDuplicateSpotID uses the same value
+	duplicateDistance = 0.0005
+)
+
+// ImportSpots reads a CSV or GeoJSON file and adds its spots with their locations.
+// Every row is checked with the rules of InsertSpot and InsertLocation, and spots that
+// already exist are skipped. The import is all or nothing: when any row has an error,
+// or in a dry run, nothing is written and the report tells what would have happened.
+func (s *SpotService) ImportSpots(ctx context.Context, format importer.Format, file io.Reader, dryRun bool) (*dto.SpotImportReportDTO, error) {
+	if !format.Valid() {
+		return nil, newValidationError("unsupported import format: %s", format)
+	}
+	records, err := importer.Read(format, file)
+	if err != nil {
+		return nil, newValidationError("invalid import file: %v", err)
+	}
+	if len(records) > MaxImportRows {
+		return nil, newValidationError("an import can have at most %d rows", MaxImportRows)
+	}
+
+	report := &dto.SpotImportReportDTO{
+		DryRun:     dryRun,
+		Rows:       len(records),
+		Errors:     []dto.SpotImportErrorDTO{},
+		Duplicates: []dto.SpotImportDuplicateDTO{},
+	}
+	err = s.queries.ExecTx(ctx, func(q interfaces.SpotQueries) error {
+		valid, err := s.checkImportRecords(ctx, q, records, report)
+		if err != nil {
+			return err
+		}
+		report.Imported = len(valid)
+		if dryRun || len(report.Errors) > 0 {
+			return nil
+		}
+
+		for _, record := range valid {
+			location, err := q.InsertLocation(ctx, db.InsertLocationParams{
+				Address:   record.Address,
+				Latitude:  record.Latitude,
+				Longitude: record.Longitude,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to insert location of row %d: %w", record.Row, err)
+			}
+			if _, err := q.InsertSpot(ctx, db.InsertSpotParams{
+				Category:    record.Category,
+				Name:        record.Name,
+				Description: record.Description,
+				LocationID:  location.ID,
+			}); err != nil {
+				return fmt.Errorf("failed to insert spot of row %d: %w", record.Row, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkImportRecords fills the errors and duplicates of the report and returns the
+// records that can be inserted
+func (s *SpotService) checkImportRecords(ctx context.Context, q interfaces.SpotQueries, records []importer.Record, report *dto.SpotImportReportDTO) ([]importer.Record, error) {
+	rows, err := q.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	categories := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		categories[row.Slug] = true
+	}
+
+	var valid []importer.Record
+	for _, record := range records {
+		if err := checkImportRecord(record, categories); err != nil {
+			report.Errors = append(report.Errors, dto.SpotImportErrorDTO{
+				Row:     record.Row,
+				Message: importErrorMessage(ctx, err),
+			})
+			continue
+		}
+
+		if earlier, ok := findImportDuplicate(valid, record); ok {
+			report.Duplicates = append(report.Duplicates, dto.SpotImportDuplicateDTO{
+				Row:            record.Row,
+				Name:           record.Name,
+				DuplicateOfRow: earlier.Row,
+			})
+			continue
+		}
+		spotID, err := q.GetDuplicateSpotID(ctx, db.GetDuplicateSpotIDParams{
+			Name:      record.Name,
+			Latitude:  record.Latitude,
+			Longitude: record.Longitude,
+		})
+		if err == nil {
+			report.Duplicates = append(report.Duplicates, dto.SpotImportDuplicateDTO{
+				Row:    record.Row,
+				Name:   record.Name,
+				SpotID: spotID,
+			})
+			continue
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to look for duplicates: %w", err)
+		}
+		valid = append(valid, record)
+	}
+	return valid, nil
+}
+
+// checkImportRecord applies the InsertSpot and InsertLocation rules to one row
+func checkImportRecord(record importer.Record, categories map[string]bool) error {
+	if record.Err != nil {
+		return record.Err
+	}
+	if err := validateSpotFields(record.Category, record.Name, record.Description); err != nil {
+		return err
+	}
+	if err := validateLocationFields(record.Address, record.Latitude, record.Longitude); err != nil {
+		return err
+	}
+	if !categories[record.Category] {
+		return newValidationError("Invalid category: %v", record.Category)
+	}
+	return nil
+}
+
+func findImportDuplicate(records []importer.Record, record importer.Record) (importer.Record, bool) {
+	for _, earlier := range records {
+		if strings.EqualFold(earlier.Name, record.Name) &&
+			math.Abs(earlier.Latitude-record.Latitude) < duplicateDistance &&
+			math.Abs(earlier.Longitude-record.Longitude) < duplicateDistance {
+			return earlier, true
+		}
+	}
+	return importer.Record{}, false
+}
+
+// importErrorMessage translates row errors for the report
+func importErrorMessage(ctx context.Context, err error) string {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return i18n.Tf(ctx, validationErr.Format, validationErr.Args...)
+	}
+	return i18n.T(ctx, err.Error())
+}
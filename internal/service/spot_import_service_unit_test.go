@@ -0,0 +1,119 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/importer"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const importCSVHeader = "category,name,description,address,latitude,longitude\n"
+
+func noDuplicateSpots(ctx context.Context, arg db.GetDuplicateSpotIDParams) (int64, error) {
+	return 0, pgx.ErrNoRows
+}
+
+func TestImportSpots_InsertsEveryRow(t *testing.T) {
+	var locations, spots int
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc:      listSeededCategories,
+		GetDuplicateSpotIDFunc: noDuplicateSpots,
+		InsertLocationFunc: func(ctx context.Context, arg db.InsertLocationParams) (db.Location, error) {
+			locations++
+			return db.Location{ID: int64(locations), Address: arg.Address}, nil
+		},
+		InsertSpotFunc: func(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error) {
+			spots++
+			if arg.LocationID != int64(spots) {
+				t.Fatalf("spot %q points at location %d", arg.Name, arg.LocationID)
+			}
+			return db.Spot{ID: int64(spots)}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	file := importCSVHeader +
+		"Gamta,Parkas,Tvenkinys,Pilaitės pr. 1,54.707,25.18\n" +
+		"Restoranai,Kavinė,Kava,Pilaitės pr. 2,54.71,25.19\n"
+	report, err := svc.ImportSpots(context.Background(), importer.FormatCSV, strings.NewReader(file), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Rows != 2 || report.Imported != 2 || spots != 2 || len(report.Errors) != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestImportSpots_RowErrorWritesNothing(t *testing.T) {
+	// InsertLocationFunc and InsertSpotFunc are not set, so writing would panic
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc:      listSeededCategories,
+		GetDuplicateSpotIDFunc: noDuplicateSpots,
+	}
+
+	svc := NewSpotService(mock)
+
+	file := importCSVHeader +
+		"Gamta,Parkas,Tvenkinys,Pilaitės pr. 1,54.707,25.18\n" +
+		"Muziejai,Muziejus,Paroda,Pilaitės pr. 3,54.71,25.19\n" +
+		"Gamta,Miškas,Takai,Pilaitės pr. 4,95,25.19\n"
+	report, err := svc.ImportSpots(context.Background(), importer.FormatCSV, strings.NewReader(file), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Errors) != 2 || report.Errors[0].Row != 3 || report.Errors[1].Row != 4 {
+		t.Fatalf("expected errors on lines 3 and 4, got %+v", report.Errors)
+	}
+	if report.Errors[0].Message != "Invalid category: Muziejai" {
+		t.Fatalf("unexpected message: %q", report.Errors[0].Message)
+	}
+}
+
+func TestImportSpots_DryRunReportsDuplicates(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		GetDuplicateSpotIDFunc: func(ctx context.Context, arg db.GetDuplicateSpotIDParams) (int64, error) {
+			if arg.Name == "Parkas" {
+				return 7, nil
+			}
+			return 0, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	file := `{"type":"FeatureCollection","features":[
+		{"geometry":{"type":"Point","coordinates":[25.18,54.707]},"properties":{"name":"Parkas","category":"Gamta","address":"Pilaitės pr. 1","description":"Tvenkinys"}},
+		{"geometry":{"type":"Point","coordinates":[25.19,54.71]},"properties":{"name":"Kavinė","category":"Restoranai","address":"Pilaitės pr. 2","description":"Kava"}},
+		{"geometry":{"type":"Point","coordinates":[25.1901,54.7101]},"properties":{"name":"kavinė","category":"Restoranai","address":"Pilaitės pr. 2","description":"Kava"}}
+	]}`
+	report, err := svc.ImportSpots(context.Background(), importer.FormatGeoJSON, strings.NewReader(file), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.DryRun || report.Imported != 1 || len(report.Duplicates) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Duplicates[0].SpotID != 7 || report.Duplicates[1].DuplicateOfRow != 2 {
+		t.Fatalf("unexpected duplicates: %+v", report.Duplicates)
+	}
+}
+
+func TestImportSpots_InvalidFile(t *testing.T) {
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	for _, format := range []importer.Format{importer.FormatCSV, importer.FormatGeoJSON, "xlsx"} {
+		_, err := svc.ImportSpots(context.Background(), format, strings.NewReader("name\n"), false)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("%s: expected validation error, got %v", format, err)
+		}
+	}
+}
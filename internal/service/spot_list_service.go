@@ -0,0 +1,264 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SpotSort string
+
+const (
+	SortByID     SpotSort = "id"
+	SortByName   SpotSort = "name"
+	SortByNewest SpotSort = "newest"
+)
+
+const (
+	DefaultSpotPageSize = 20
+	MaxSpotPageSize     = 100
+)
+
+// SpotListQuery describes one page of a spot list. Zero values mean
+// all categories, sorted by id, DefaultSpotPageSize items from the start.
+type SpotListQuery struct {
+	Categories    []string
+	Sort          SpotSort
+	Limit         int
+	Cursor        string
+	IncludeSecret bool
+	// UserID marks the user's favorites in the page, 0 for anonymous requests
+	UserID int64
+	// OpenAt keeps only spots whose opening hours say they are open at that moment
+	OpenAt *time.Time
+	// Equipment keeps only spots that have all of the listed, not broken, equipment
+	Equipment []db.EquipmentType
+}
+
+// spotCursor is the position after the last returned spot. It is sent to clients
+// base64 encoded, so they treat it as opaque and we can change it later.
+type spotCursor struct {
+	Sort      SpotSort  `json:"s"`
+	ID        int64     `json:"id"`
+	Name      string    `json:"n,omitempty"`
+	CreatedAt time.Time `json:"t"`
+}
+
+// ListSpots returns a page of spot cards together with the total number of matching spots
+func (s *SpotService) ListSpots(ctx context.Context, q SpotListQuery) (*dto.SpotPageDTO, error) {
+	if q.Sort == "" {
+		q.Sort = SortByID
+	}
+	if q.Sort != SortByID && q.Sort != SortByName && q.Sort != SortByNewest {
+		return nil, newValidationError("invalid sort: %s", q.Sort)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultSpotPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxSpotPageSize {
+		return nil, newValidationError("limit must be between 1 and %d, got: %d", MaxSpotPageSize, q.Limit)
+	}
+
+	categories, err := s.checkListCategories(ctx, q.Categories, q.IncludeSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	equipment := []string{}
+	seen := make(map[db.EquipmentType]bool)
+	for _, equipmentType := range q.Equipment {
+		if !equipmentType.Valid() {
+			return nil, newValidationError("invalid equipment type: %v", equipmentType)
+		}
+		// Counted per distinct type in the query, so duplicates would never match
+		if !seen[equipmentType] {
+			seen[equipmentType] = true
+			equipment = append(equipment, string(equipmentType))
+		}
+	}
+
+	var openDate pgtype.Date
+	var openTime pgtype.Time
+	if q.OpenAt != nil {
+		openDate, openTime = openFilterParams(*q.OpenAt)
+	}
+
+	params := db.ListSpotsByIDParams{
+		IncludeSecret: q.IncludeSecret,
+		Categories:    categories,
+		FilterOpen:    q.OpenAt != nil,
+		OpenDate:      openDate,
+		OpenTime:      openTime,
+		Equipment:     equipment,
+		// One extra row tells whether there is a next page
+		MaxResults: int32(q.Limit + 1),
+	}
+	var cursor spotCursor
+	if q.Cursor != "" {
+		cursor, err = decodeSpotCursor(q.Cursor)
+		if err != nil || cursor.Sort != q.Sort {
+			return nil, newValidationError("invalid cursor")
+		}
+		params.HasCursor = true
+		params.CursorID = cursor.ID
+	}
+
+	rows, err := s.listSpotRows(ctx, q.Sort, params, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spots: %w", err)
+	}
+	total, err := s.queries.CountSpots(ctx, db.CountSpotsParams{
+		IncludeSecret: q.IncludeSecret,
+		Categories:    categories,
+		FilterOpen:    q.OpenAt != nil,
+		OpenDate:      openDate,
+		OpenTime:      openTime,
+		Equipment:     equipment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count spots: %w", err)
+	}
+
+	page := &dto.SpotPageDTO{Items: []dto.SpotCardDTO{}, Total: total}
+	if len(rows) > q.Limit {
+		rows = rows[:q.Limit]
+		last := rows[len(rows)-1]
+		next, err := encodeSpotCursor(spotCursor{
+			Sort:      q.Sort,
+			ID:        last.ID,
+			Name:      last.Name,
+			CreatedAt: last.CreatedAt.Time,
+		})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = &next
+	}
+
+	var withHours []int64
+	for _, row := range rows {
+		if row.HasOpeningHours {
+			withHours = append(withHours, row.ID)
+		}
+		page.Items = append(page.Items, dto.SpotCardDTO{
+			ID:            row.ID,
+			Name:          row.Name,
+			Category:      row.Category,
+			Address:       row.Address,
+			ImageURL:      row.ImageUrl,
+			AverageRating: row.AverageRating,
+			ReviewCount:   row.ReviewCount,
+			Latitude:      row.Latitude,
+			Longitude:     row.Longitude,
+		})
+	}
+
+	if err := s.nameCategories(ctx, page.Items); err != nil {
+		return nil, err
+	}
+	if err := s.markFavorites(ctx, q.UserID, page.Items); err != nil {
+		return nil, err
+	}
+	if err := s.markOpeningStatus(ctx, page.Items, withHours, time.Now()); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// listSpotRows runs the list query of the sort order. The queries only differ in
+// their cursor and ORDER BY, so the rows of all of them are ListSpotsByIDRows.
+func (s *SpotService) listSpotRows(ctx context.Context, sort SpotSort, params db.ListSpotsByIDParams, cursor spotCursor) ([]db.ListSpotsByIDRow, error) {
+	switch sort {
+	case SortByName:
+		rows, err := s.queries.ListSpotsByName(ctx, db.ListSpotsByNameParams{
+			IncludeSecret: params.IncludeSecret,
+			Categories:    params.Categories,
+			FilterOpen:    params.FilterOpen,
+			OpenDate:      params.OpenDate,
+			OpenTime:      params.OpenTime,
+			Equipment:     params.Equipment,
+			HasCursor:     params.HasCursor,
+			CursorName:    cursor.Name,
+			CursorID:      params.CursorID,
+			MaxResults:    params.MaxResults,
+		})
+		converted := make([]db.ListSpotsByIDRow, len(rows))
+		for i, row := range rows {
+			converted[i] = db.ListSpotsByIDRow(row)
+		}
+		return converted, err
+	case SortByNewest:
+		rows, err := s.queries.ListSpotsByNewest(ctx, db.ListSpotsByNewestParams{
+			IncludeSecret:   params.IncludeSecret,
+			Categories:      params.Categories,
+			FilterOpen:      params.FilterOpen,
+			OpenDate:        params.OpenDate,
+			OpenTime:        params.OpenTime,
+			Equipment:       params.Equipment,
+			HasCursor:       params.HasCursor,
+			CursorCreatedAt: pgtype.Timestamp{Time: cursor.CreatedAt, Valid: true},
+			CursorID:        params.CursorID,
+			MaxResults:      params.MaxResults,
+		})
+		converted := make([]db.ListSpotsByIDRow, len(rows))
+		for i, row := range rows {
+			converted[i] = db.ListSpotsByIDRow(row)
+		}
+		return converted, err
+	default:
+		return s.queries.ListSpotsByID(ctx, params)
+	}
+}
+
+// checkListCategories makes sure every filter category exists and is visible to the caller
+func (s *SpotService) checkListCategories(ctx context.Context, slugs []string, includeSecret bool) ([]string, error) {
+	categories := make([]string, 0, len(slugs))
+	if len(slugs) == 0 {
+		return categories, nil
+	}
+
+	rows, err := s.queries.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	known := make(map[string]db.Category, len(rows))
+	for _, row := range rows {
+		known[row.Slug] = row
+	}
+
+	for _, slug := range slugs {
+		category, ok := known[slug]
+		if !ok {
+			return nil, newValidationError("invalid category: %v", slug)
+		}
+		if category.RequiresAuth && !includeSecret {
+			return nil, newValidationError("secret category not accessible through public endpoint")
+		}
+		categories = append(categories, slug)
+	}
+	return categories, nil
+}
+
+func encodeSpotCursor(cursor spotCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSpotCursor(value string) (spotCursor, error) {
+	var cursor spotCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
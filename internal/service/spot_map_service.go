@@ -0,0 +1,178 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	MaxMapZoom = 22
+	// MinSpotZoom is the first zoom level where single spots are returned instead of clusters
+	MinSpotZoom = 15
+	// maxMapSpots caps single spots per viewport, busier viewports are clustered even when zoomed in
+	maxMapSpots = 500
+	// maxClusterRows caps the per category cell rows, a large viewport at a high zoom
+	// would otherwise group every spot into its own tiny cell
+	maxClusterRows = 5000
+	// clusterCellsPerTile splits every 256px map tile into a 4x4 grid of roughly 64px cells
+	clusterCellsPerTile = 4
+)
+
+// BBox is a viewport in degrees, it may not cross the antimeridian
+type BBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+func (b BBox) validate() error {
+	if b.MinLat < -90 || b.MaxLat > 90 || b.MinLat > b.MaxLat {
+		return newValidationError("minLat and maxLat must be between -90 and 90 and minLat cannot be above maxLat")
+	}
+	if b.MinLng < -180 || b.MaxLng > 180 || b.MinLng > b.MaxLng {
+		return newValidationError("minLng and maxLng must be between -180 and 180 and minLng cannot be above maxLng")
+	}
+	return nil
+}
+
+// GetSpotsInBBox returns the spots of a map viewport. From MinSpotZoom on single spots are
+// returned, below that (or when the viewport holds too many spots) grid clusters.
+// Single spots mark the favorites of userID, 0 for anonymous requests, and carry their
+// opening status.
+func (s *SpotService) GetSpotsInBBox(ctx context.Context, bbox BBox, zoom int, includeSecret bool, userID int64) (*dto.SpotMapDTO, error) {
+	if err := bbox.validate(); err != nil {
+		return nil, err
+	}
+	if zoom < 0 || zoom > MaxMapZoom {
+		return nil, newValidationError("zoom must be between 0 and %d, got: %d", MaxMapZoom, zoom)
+	}
+
+	if zoom >= MinSpotZoom {
+		rows, err := s.queries.GetSpotsInBBox(ctx, db.GetSpotsInBBoxParams{
+			MinLat:        bbox.MinLat,
+			MaxLat:        bbox.MaxLat,
+			MinLng:        bbox.MinLng,
+			MaxLng:        bbox.MaxLng,
+			IncludeSecret: includeSecret,
+			MaxResults:    maxMapSpots + 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spots in viewport: %w", err)
+		}
+
+		if len(rows) <= maxMapSpots {
+			var withHours []int64
+			dtos := make([]dto.SpotCardDTO, len(rows))
+			for i, row := range rows {
+				if row.HasOpeningHours {
+					withHours = append(withHours, row.ID)
+				}
+				dtos[i] = dto.SpotCardDTO{
+					ID:            row.ID,
+					Name:          row.Name,
+					Category:      row.Category,
+					Address:       row.Address,
+					ImageURL:      row.ImageUrl,
+					AverageRating: row.AverageRating,
+					ReviewCount:   row.ReviewCount,
+					Latitude:      row.Latitude,
+					Longitude:     row.Longitude,
+				}
+			}
+			if err := s.nameCategories(ctx, dtos); err != nil {
+				return nil, err
+			}
+			if err := s.markFavorites(ctx, userID, dtos); err != nil {
+				return nil, err
+			}
+			if err := s.markOpeningStatus(ctx, dtos, withHours, time.Now()); err != nil {
+				return nil, err
+			}
+			return &dto.SpotMapDTO{Zoom: zoom, Spots: dtos}, nil
+		}
+	}
+
+	rows, err := s.queries.GetSpotClustersInBBox(ctx, db.GetSpotClustersInBBoxParams{
+		CellSize:      clusterCellSize(zoom),
+		MinLat:        bbox.MinLat,
+		MaxLat:        bbox.MaxLat,
+		MinLng:        bbox.MinLng,
+		MaxLng:        bbox.MaxLng,
+		IncludeSecret: includeSecret,
+		// One extra row tells whether the last cell was cut off
+		MaxResults: maxClusterRows + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spot clusters: %w", err)
+	}
+
+	return &dto.SpotMapDTO{
+		Zoom:      zoom,
+		Clustered: true,
+		Spots:     []dto.SpotCardDTO{},
+		Clusters:  mergeClusterRows(trimClusterRows(rows, maxClusterRows)),
+	}, nil
+}
+
+// trimClusterRows keeps at most limit rows without splitting a cell, a cell missing some of
+// its categories would show a wrong count
+func trimClusterRows(rows []db.GetSpotClustersInBBoxRow, limit int) []db.GetSpotClustersInBBoxRow {
+	if len(rows) <= limit {
+		return rows
+	}
+	cut := rows[limit]
+	rows = rows[:limit]
+	for len(rows) > 0 && rows[len(rows)-1].CellY == cut.CellY && rows[len(rows)-1].CellX == cut.CellX {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+// clusterCellSize returns the grid cell size in degrees for a web map zoom level
+func clusterCellSize(zoom int) float64 {
+	return 360 / math.Pow(2, float64(zoom)) / clusterCellsPerTile
+}
+
+// mergeClusterRows folds the per category rows of each grid cell into one cluster.
+// Rows must be ordered by cell, as GetSpotClustersInBBox returns them.
+func mergeClusterRows(rows []db.GetSpotClustersInBBoxRow) []dto.SpotClusterDTO {
+	clusters := []dto.SpotClusterDTO{}
+	var latSum, lngSum float64
+	var sampleID int64
+
+	for i, row := range rows {
+		newCell := i == 0 || row.CellY != rows[i-1].CellY || row.CellX != rows[i-1].CellX
+		if newCell {
+			if i > 0 {
+				clusters[len(clusters)-1] = finishCluster(clusters[len(clusters)-1], latSum, lngSum, sampleID)
+			}
+			clusters = append(clusters, dto.SpotClusterDTO{Categories: map[string]int64{}})
+			latSum, lngSum, sampleID = 0, 0, row.SampleID
+		}
+
+		cluster := &clusters[len(clusters)-1]
+		cluster.Count += row.SpotCount
+		cluster.Categories[row.Category] += row.SpotCount
+		// Weighted by count, so the centroid is the average of all spots in the cell
+		latSum += row.AvgLatitude * float64(row.SpotCount)
+		lngSum += row.AvgLongitude * float64(row.SpotCount)
+	}
+	if len(clusters) > 0 {
+		clusters[len(clusters)-1] = finishCluster(clusters[len(clusters)-1], latSum, lngSum, sampleID)
+	}
+	return clusters
+}
+
+func finishCluster(cluster dto.SpotClusterDTO, latSum, lngSum float64, sampleID int64) dto.SpotClusterDTO {
+	cluster.Latitude = latSum / float64(cluster.Count)
+	cluster.Longitude = lngSum / float64(cluster.Count)
+	if cluster.Count == 1 {
+		cluster.SpotID = &sampleID
+	}
+	return cluster
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxSearchQueryLength = 100
+	maxSearchResults     = 50
+
+	// Match markers put into snippets by the SearchSpots query
+	snippetStartSel = "\x02"
+	snippetStopSel  = "\x03"
+)
+
+// SearchSpots runs a full-text search over spot names, descriptions and addresses.
+// Lithuanian diacritics are folded, so "zalias" matches "Žalias". The favorites of
+// userID are marked, 0 for anonymous requests, together with the opening status.
+func (s *SpotService) SearchSpots(ctx context.Context, query string, includeSecret bool, userID int64) ([]dto.SpotSearchResultDTO, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, newValidationError("search query cannot be empty")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, newValidationError("search query cannot be longer than %d characters", maxSearchQueryLength)
+	}
+
+	rows, err := s.queries.SearchSpots(ctx, db.SearchSpotsParams{
+		Query:         query,
+		IncludeSecret: includeSecret,
+		MaxResults:    maxSearchResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search spots: %w", err)
+	}
+
+	var names map[string]string
+	if len(rows) > 0 {
+		names, err = categoryNames(ctx, s.queries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var withHours []int64
+	cards := make([]dto.SpotCardDTO, len(rows))
+	for i, row := range rows {
+		if row.HasOpeningHours {
+			withHours = append(withHours, row.ID)
+		}
+		cards[i] = dto.SpotCardDTO{
+			ID:            row.ID,
+			Name:          row.Name,
+			Category:      row.Category,
+			CategoryName:  names[row.Category],
+			Address:       row.Address,
+			ImageURL:      row.ImageUrl,
+			AverageRating: row.AverageRating,
+			ReviewCount:   row.ReviewCount,
+			Latitude:      row.Latitude,
+			Longitude:     row.Longitude,
+		}
+	}
+	if err := s.markFavorites(ctx, userID, cards); err != nil {
+		return nil, err
+	}
+	if err := s.markOpeningStatus(ctx, cards, withHours, time.Now()); err != nil {
+		return nil, err
+	}
+
+	dtos := make([]dto.SpotSearchResultDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = dto.SpotSearchResultDTO{
+			SpotCardDTO: cards[i],
+			Rank:        row.Rank,
+			Snippet:     highlightSnippet(row.Snippet),
+		}
+	}
+	return dtos, nil
+}
+
+// highlightSnippet escapes the snippet text and only then turns the match markers into
+// <mark> tags, so markup stored in spot descriptions is never rendered
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, snippetStartSel, "<mark>")
+	return strings.ReplaceAll(escaped, snippetStopSel, "</mark>")
+}
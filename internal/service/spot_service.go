@@ -5,7 +5,11 @@ import (
 	"PilaiteProject/internal/dto"
 	"PilaiteProject/internal/interfaces"
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type SpotService struct {
@@ -16,19 +20,16 @@ func NewSpotService(queries interfaces.SpotQueries) *SpotService {
 	return &SpotService{queries: queries}
 }
 
-func (s *SpotService) InsertSpot(ctx context.Context, category db.SpotCategory, name, description string, location_id int64) (*db.Spot, error) {
+func (s *SpotService) InsertSpot(ctx context.Context, category string, name, description string, location_id int64) (*db.Spot, error) {
 	// Basic validation
-	if !category.Valid() {
-		return nil, fmt.Errorf("Invalid category: %v", category)
-	}
-	if name == "" {
-		return nil, fmt.Errorf("name cannot be empty")
-	}
-	if description == "" {
-		return nil, fmt.Errorf("description cannot be empty")
+	if err := validateSpotFields(category, name, description); err != nil {
+		return nil, err
 	}
 	if location_id <= 0 {
-		return nil, fmt.Errorf("invalid location_id: %d", location_id)
+		return nil, newValidationError("invalid location_id: %d", location_id)
+	}
+	if _, err := checkCategory(ctx, s.queries, category); err != nil {
+		return nil, err
 	}
 
 	spot, err := s.queries.InsertSpot(ctx, db.InsertSpotParams{
@@ -52,6 +53,26 @@ func (s *SpotService) GetSpotById(ctx context.Context, id int64) (*db.Spot, erro
 	return &spot, nil
 }
 
+// getVisibleSpot returns the spot, or ErrSpotNotFound when it does not exist or its
+// category is hidden from the caller
+func (s *SpotService) getVisibleSpot(ctx context.Context, spotID int64, includeSecret bool) (db.Spot, error) {
+	spot, err := s.queries.GetSpotByID(ctx, spotID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Spot{}, ErrSpotNotFound
+	}
+	if err != nil {
+		return db.Spot{}, fmt.Errorf("failed to get spot: %w", err)
+	}
+	visible, err := spotVisible(ctx, s.queries, spot, includeSecret)
+	if err != nil {
+		return db.Spot{}, err
+	}
+	if !visible {
+		return db.Spot{}, ErrSpotNotFound
+	}
+	return spot, nil
+}
+
 func (s *SpotService) GetAllSpots(ctx context.Context) ([]db.Spot, error) {
 	spots, err := s.queries.GetAllSpots(ctx)
 	if err != nil {
@@ -60,106 +81,299 @@ func (s *SpotService) GetAllSpots(ctx context.Context) ([]db.Spot, error) {
 	return spots, nil
 }
 
-func (s *SpotService) GetPublicSpotsWithDetails(ctx context.Context) ([]dto.SpotCardDTO, error) {
-	rows, err := s.queries.GetPublicSpotsWithDetails(ctx)
+const (
+	// DefaultNearbyRadiusM is used when the client does not send a radius
+	DefaultNearbyRadiusM = 1000.0
+	MaxNearbyRadiusM     = 50000.0
+	maxNearbyResults     = 100
+)
+
+// GetSpotsNearby returns spots within radiusM meters of the point, closest first.
+// Secret spots are only included when includeSecret is set, i.e. for logged in users.
+// The favorites of userID are marked, 0 for anonymous requests.
+func (s *SpotService) GetSpotsNearby(ctx context.Context, lat, lng, radiusM float64, includeSecret bool, userID int64) ([]dto.SpotCardDTO, error) {
+	if lat < -90 || lat > 90 {
+		return nil, newValidationError("latitude must be between -90 and 90, got: %f", lat)
+	}
+	if lng < -180 || lng > 180 {
+		return nil, newValidationError("longitude must be between -180 and 180, got: %f", lng)
+	}
+	if radiusM <= 0 || radiusM > MaxNearbyRadiusM {
+		return nil, newValidationError("radius must be between 0 and %.0f meters, got: %f", MaxNearbyRadiusM, radiusM)
+	}
+
+	minLat, maxLat, minLng, maxLng := boundingBox(lat, lng, radiusM)
+
+	rows, err := s.queries.GetSpotsNearby(ctx, db.GetSpotsNearbyParams{
+		Lat:           lat,
+		Lng:           lng,
+		MinLat:        minLat,
+		MaxLat:        maxLat,
+		MinLng:        minLng,
+		MaxLng:        maxLng,
+		IncludeSecret: includeSecret,
+		RadiusM:       radiusM,
+		MaxResults:    maxNearbyResults,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get spots with details: %w", err)
+		return nil, fmt.Errorf("failed to get nearby spots: %w", err)
 	}
 
+	var withHours []int64
 	dtos := make([]dto.SpotCardDTO, len(rows))
 	for i, row := range rows {
+		if row.HasOpeningHours {
+			withHours = append(withHours, row.ID)
+		}
+		distance := row.DistanceM
 		dtos[i] = dto.SpotCardDTO{
-			ID:        row.ID,
-			Name:      row.Name,
-			Category:  string(row.Category),
-			Address:   row.Address,
-			ImageURL:  row.ImageUrl,
-			Latitude:  row.Latitude,
-			Longitude: row.Longitude,
+			ID:            row.ID,
+			Name:          row.Name,
+			Category:      row.Category,
+			Address:       row.Address,
+			ImageURL:      row.ImageUrl,
+			AverageRating: row.AverageRating,
+			ReviewCount:   row.ReviewCount,
+			Latitude:      row.Latitude,
+			Longitude:     row.Longitude,
+			DistanceM:     &distance,
 		}
 	}
+
+	if err := s.nameCategories(ctx, dtos); err != nil {
+		return nil, err
+	}
+	if err := s.markFavorites(ctx, userID, dtos); err != nil {
+		return nil, err
+	}
+	if err := s.markOpeningStatus(ctx, dtos, withHours, time.Now()); err != nil {
+		return nil, err
+	}
 	return dtos, nil
 }
 
-func (s SpotService) GetSpotsWithDetails(ctx context.Context) ([]dto.SpotCardDTO, error) {
-	rows, err := s.queries.GetSpotsWithDetails(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get spots with details: %w", err)
+// SpotInput holds the spot fields together with the address and coordinates of its location
+type SpotInput struct {
+	Category    string
+	Name        string
+	Description string
+	Address     string
+	Latitude    float64
+	Longitude   float64
+}
+
+// SpotPatch holds the fields of a partial update, nil means "keep the current value"
+type SpotPatch struct {
+	Category    *string
+	Name        *string
+	Description *string
+	Address     *string
+	Latitude    *float64
+	Longitude   *float64
+}
+
+func (in SpotInput) validate() error {
+	if err := validateSpotFields(in.Category, in.Name, in.Description); err != nil {
+		return err
 	}
+	return validateLocationFields(in.Address, in.Latitude, in.Longitude)
+}
 
-	dtos := make([]dto.SpotCardDTO, len(rows))
-	for i, row := range rows {
-		dtos[i] = dto.SpotCardDTO{
-			ID:        row.ID,
-			Name:      row.Name,
-			Category:  string(row.Category),
-			Address:   row.Address,
-			ImageURL:  row.ImageUrl,
-			Latitude:  row.Latitude,
-			Longitude: row.Longitude,
+// CreateSpotWithLocation inserts the location and the spot pointing at it in one transaction
+func (s *SpotService) CreateSpotWithLocation(ctx context.Context, in SpotInput) (*dto.SpotDetailDTO, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	var result *dto.SpotDetailDTO
+	err := s.queries.ExecTx(ctx, func(q interfaces.SpotQueries) error {
+		if _, err := checkCategory(ctx, q, in.Category); err != nil {
+			return err
+		}
+
+		location, err := q.InsertLocation(ctx, db.InsertLocationParams{
+			Address:   in.Address,
+			Latitude:  in.Latitude,
+			Longitude: in.Longitude,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert location: %w", err)
 		}
+
+		spot, err := q.InsertSpot(ctx, db.InsertSpotParams{
+			Category:    in.Category,
+			Name:        in.Name,
+			Description: in.Description,
+			LocationID:  location.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert a spot: %w", err)
+		}
+
+		result = toSpotDetailDTO(spot, location)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return dtos, nil
+	return result, nil
 }
 
-func (s *SpotService) GetPublicSpotsByCategoryWithDetails(ctx context.Context, category db.SpotCategory) ([]dto.SpotCardDTO, error) {
-	if !category.Valid() {
-		return nil, fmt.Errorf("invalid category: %v", category)
+// UpdateSpotWithLocation replaces every field of the spot and its location in one transaction
+func (s *SpotService) UpdateSpotWithLocation(ctx context.Context, id int64, in SpotInput) (*dto.SpotDetailDTO, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
 	}
 
-	if category == db.SpotCategorySlaptosVietos {
-		return nil, fmt.Errorf("secret category not accessible through public endpoint")
+	var result *dto.SpotDetailDTO
+	err := s.queries.ExecTx(ctx, func(q interfaces.SpotQueries) error {
+		var err error
+		result, err = updateSpotAndLocation(ctx, q, id, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	rows, err := s.queries.GetSpotsByCategoryWithDetails(ctx, category)
+// PatchSpotWithLocation updates only the fields present in the patch. The spot row is
+// locked while the patch is merged, so concurrent patches of other fields are kept.
+func (s *SpotService) PatchSpotWithLocation(ctx context.Context, id int64, patch SpotPatch) (*dto.SpotDetailDTO, error) {
+	var result *dto.SpotDetailDTO
+	err := s.queries.ExecTx(ctx, func(q interfaces.SpotQueries) error {
+		spot, err := q.GetSpotByIDForUpdate(ctx, id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSpotNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get spot: %w", err)
+		}
+
+		location, err := q.GetLocationByID(ctx, spot.LocationID)
+		if err != nil {
+			return fmt.Errorf("failed to get location: %w", err)
+		}
+
+		in := SpotInput{
+			Category:    spot.Category,
+			Name:        spot.Name,
+			Description: spot.Description,
+			Address:     location.Address,
+			Latitude:    location.Latitude,
+			Longitude:   location.Longitude,
+		}
+		if patch.Category != nil {
+			in.Category = *patch.Category
+		}
+		if patch.Name != nil {
+			in.Name = *patch.Name
+		}
+		if patch.Description != nil {
+			in.Description = *patch.Description
+		}
+		if patch.Address != nil {
+			in.Address = *patch.Address
+		}
+		if patch.Latitude != nil {
+			in.Latitude = *patch.Latitude
+		}
+		if patch.Longitude != nil {
+			in.Longitude = *patch.Longitude
+		}
+
+		if err := in.validate(); err != nil {
+			return err
+		}
+
+		result, err = updateSpotAndLocation(ctx, q, id, in)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get spots by category: %w", err)
+		return nil, err
 	}
+	return result, nil
+}
 
-	dtos := make([]dto.SpotCardDTO, len(rows))
-	for i, row := range rows {
-		dtos[i] = dto.SpotCardDTO{
-			ID:        row.ID,
-			Name:      row.Name,
-			Category:  string(row.Category),
-			Address:   row.Address,
-			ImageURL:  row.ImageUrl,
-			Latitude:  row.Latitude,
-			Longitude: row.Longitude,
+// DeleteSpotWithLocation removes the spot together with its images and its location,
+// so no orphaned rows are left behind. The deleted image rows are returned so their
+// files can be removed once the transaction is committed.
+func (s *SpotService) DeleteSpotWithLocation(ctx context.Context, id int64) ([]db.Image, error) {
+	var images []db.Image
+	err := s.queries.ExecTx(ctx, func(q interfaces.SpotQueries) error {
+		var err error
+		images, err = q.DeleteImagesBySpotID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete images: %w", err)
+		}
+
+		spot, err := q.DeleteSpot(ctx, id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSpotNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete spot: %w", err)
+		}
+
+		if err := q.DeleteLocation(ctx, spot.LocationID); err != nil {
+			return fmt.Errorf("failed to delete location: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return dtos, nil
+	return images, nil
 }
 
-func (s *SpotService) GetSpotsByCategoryWithDetails(ctx context.Context, category db.SpotCategory) ([]dto.SpotCardDTO, error) {
-	if !category.Valid() {
-		return nil, fmt.Errorf("invalid category: %v", category)
+// updateSpotAndLocation writes the validated input. Equipment is removed when the spot
+// is in a category without equipment, so the equipment filter can't match it anymore.
+func updateSpotAndLocation(ctx context.Context, q interfaces.SpotQueries, id int64, in SpotInput) (*dto.SpotDetailDTO, error) {
+	category, err := checkCategory(ctx, q, in.Category)
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err := s.queries.GetSpotsByCategoryWithDetails(ctx, category)
+	spot, err := q.UpdateSpot(ctx, db.UpdateSpotParams{
+		ID:          id,
+		Category:    in.Category,
+		Name:        in.Name,
+		Description: in.Description,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSpotNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get spots by category: %w", err)
+		return nil, fmt.Errorf("failed to update spot: %w", err)
 	}
-
-	dtos := make([]dto.SpotCardDTO, len(rows))
-	for i, row := range rows {
-		dtos[i] = dto.SpotCardDTO{
-			ID:        row.ID,
-			Name:      row.Name,
-			Category:  string(row.Category),
-			Address:   row.Address,
-			ImageURL:  row.ImageUrl,
-			Latitude:  row.Latitude,
-			Longitude: row.Longitude,
+	if !category.HasEquipment {
+		if err := q.DeleteSpotEquipmentBySpotID(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to delete equipment: %w", err)
 		}
 	}
-	return dtos, nil
+
+	location, err := q.UpdateLocation(ctx, db.UpdateLocationParams{
+		ID:        spot.LocationID,
+		Address:   in.Address,
+		Latitude:  in.Latitude,
+		Longitude: in.Longitude,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return toSpotDetailDTO(spot, location), nil
 }
 
-func (s *SpotService) StringToSpotCategory(categoryStr string) (db.SpotCategory, error) {
-	category := db.SpotCategory(categoryStr)
-	if !category.Valid() {
-		return "", fmt.Errorf("invalid spot category: %s", categoryStr)
+func toSpotDetailDTO(spot db.Spot, location db.Location) *dto.SpotDetailDTO {
+	return &dto.SpotDetailDTO{
+		ID:          spot.ID,
+		Category:    spot.Category,
+		Name:        spot.Name,
+		Description: spot.Description,
+		LocationID:  location.ID,
+		Address:     location.Address,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
 	}
-	return category, nil
 }
@@ -5,16 +5,25 @@ import (
 	"PilaiteProject/internal/mocks"
 	"context"
 	"errors"
+	"math"
 	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-func TestGetSpotsByCategory_Success(t *testing.T) {
+func TestListSpots_ByCategory_Success(t *testing.T) {
 	mock := &mocks.MockSpotQueries{
-		GetSpotsByCategoryWithDetailsFunc: func(ctx context.Context, category db.SpotCategory) ([]db.GetSpotsByCategoryWithDetailsRow, error) {
-			return []db.GetSpotsByCategoryWithDetailsRow{
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+			if len(arg.Categories) != 1 || arg.Categories[0] != "Slaptos_vietos" {
+				t.Fatalf("unexpected categories: %v", arg.Categories)
+			}
+			return []db.ListSpotsByIDRow{
 				{
 					ID:        1,
-					Category:  category,
+					Category:  "Slaptos_vietos",
 					Name:      "Beach",
 					Address:   "123 Beach St",
 					ImageUrl:  "https://example.com/beach.jpg",
@@ -23,7 +32,7 @@ func TestGetSpotsByCategory_Success(t *testing.T) {
 				},
 				{
 					ID:        2,
-					Category:  category,
+					Category:  "Slaptos_vietos",
 					Name:      "Mountain",
 					Address:   "456 Mountain Rd",
 					ImageUrl:  "https://example.com/mountain.jpg",
@@ -32,37 +41,42 @@ func TestGetSpotsByCategory_Success(t *testing.T) {
 				},
 			}, nil
 		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			return 2, nil
+		},
 	}
 
 	svc := NewSpotService(mock)
 	ctx := context.Background()
 
-	category := db.SpotCategorySlaptosVietos
-
-	spots, err := svc.GetSpotsByCategoryWithDetails(ctx, category)
+	page, err := svc.ListSpots(ctx, SpotListQuery{
+		Categories:    []string{"Slaptos_vietos"},
+		IncludeSecret: true,
+	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(spots) != 2 {
-		t.Fatalf("expected 2 spots, got %d", len(spots))
+	if len(page.Items) != 2 || page.Total != 2 {
+		t.Fatalf("expected 2 spots, got %d (total %d)", len(page.Items), page.Total)
 	}
-	if spots[0].Name != "Beach" {
-		t.Fatalf("expected first spot name 'Beach', got %s", spots[0].Name)
+	if page.Items[0].Name != "Beach" {
+		t.Fatalf("expected first spot name 'Beach', got %s", page.Items[0].Name)
+	}
+	if page.NextCursor != nil {
+		t.Fatalf("expected no next cursor on the last page, got %s", *page.NextCursor)
 	}
-
-	t.Log(spots)
 }
 
-func TestGetSpotsByCategory_InvalidCategory(t *testing.T) {
-	mock := &mocks.MockSpotQueries{}
+func TestListSpots_InvalidCategory(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+	}
 
 	svc := NewSpotService(mock)
 	ctx := context.Background()
 
-	invalidCategory := db.SpotCategory("bad")
-
-	_, err := svc.GetPublicSpotsByCategoryWithDetails(ctx, invalidCategory)
+	_, err := svc.ListSpots(ctx, SpotListQuery{Categories: []string{"bad"}})
 	if err == nil {
 		t.Fatal("expected error for invalid category, got nil")
 	}
@@ -72,9 +86,9 @@ func TestGetSpotsByCategory_InvalidCategory(t *testing.T) {
 	}
 }
 
-func TestGetSpotsByCategory_DBError(t *testing.T) {
+func TestListSpots_DBError(t *testing.T) {
 	mock := &mocks.MockSpotQueries{
-		GetSpotsByCategoryWithDetailsFunc: func(ctx context.Context, category db.SpotCategory) ([]db.GetSpotsByCategoryWithDetailsRow, error) {
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
 			return nil, errors.New("db error")
 		},
 	}
@@ -82,74 +96,660 @@ func TestGetSpotsByCategory_DBError(t *testing.T) {
 	svc := NewSpotService(mock)
 	ctx := context.Background()
 
-	category := db.SpotCategorySlaptosVietos
-
-	_, err := svc.GetSpotsByCategoryWithDetails(ctx, category)
+	_, err := svc.ListSpots(ctx, SpotListQuery{IncludeSecret: true})
 	if err == nil {
 		t.Fatal("expected error from DB, got nil")
 	}
 }
 
-func TestGetPublicSpotsByCategory_Success(t *testing.T) {
+func TestListSpots_Public_HidesSecret(t *testing.T) {
 	mock := &mocks.MockSpotQueries{
-		GetSpotsByCategoryWithDetailsFunc: func(ctx context.Context, category db.SpotCategory) ([]db.GetSpotsByCategoryWithDetailsRow, error) {
-			return []db.GetSpotsByCategoryWithDetailsRow{
-				{
-					ID:        1,
-					Name:      "Park",
-					Category:  category,
-					Address:   "789 Park Ave",
-					ImageUrl:  "https://example.com/park.jpg",
-					Latitude:  54.6500,
-					Longitude: 25.2500,
-				},
-				{
-					ID:        2,
-					Name:      "Lake",
-					Category:  category,
-					Address:   "321 Lake Dr",
-					ImageUrl:  "https://example.com/lake.jpg",
-					Latitude:  54.6800,
-					Longitude: 25.2800,
-				},
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+			if arg.IncludeSecret {
+				t.Fatal("public list must not include secret spots")
+			}
+			return []db.ListSpotsByIDRow{
+				{ID: 1, Name: "Park", Category: "Gamta"},
+				{ID: 2, Name: "Lake", Category: "Gamta"},
 			}, nil
 		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			if arg.IncludeSecret {
+				t.Fatal("public count must not include secret spots")
+			}
+			return 2, nil
+		},
 	}
 
 	svc := NewSpotService(mock)
 	ctx := context.Background()
-	category := db.SpotCategoryGamta
 
-	spots, err := svc.GetPublicSpotsByCategoryWithDetails(ctx, category)
+	page, err := svc.ListSpots(ctx, SpotListQuery{Categories: []string{"Gamta"}})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(spots) != 2 {
-		t.Fatalf("expected 2 spots, got %d", len(spots))
+	if len(page.Items) != 2 {
+		t.Fatalf("expected 2 spots, got %d", len(page.Items))
 	}
-	t.Log(spots)
 }
 
-func TestGetPublicSpotsByCategory_SecretCategory(t *testing.T) {
+func TestListSpots_Public_SecretCategory(t *testing.T) {
+	svc := NewSpotService(&mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+	})
+	ctx := context.Background()
+
+	_, err := svc.ListSpots(ctx, SpotListQuery{Categories: []string{"Slaptos_vietos"}})
+	if err == nil {
+		t.Fatal("expected error for secret category, got nil")
+	}
+
+	expected := "secret category not accessible through public endpoint"
+	if err.Error() != expected {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+}
+
+func TestListSpots_Public_NewCategoryRequiringAuth(t *testing.T) {
 	mock := &mocks.MockSpotQueries{
-		GetPublicSpotsByCategoryWithDetailsFunc: func(ctx context.Context, category db.SpotCategory) ([]db.GetPublicSpotsByCategoryWithDetailsRow, error) {
-			return nil, nil
+		GetCategoriesFunc: func(ctx context.Context) ([]db.Category, error) {
+			return append(seededCategories(), db.Category{Slug: "Stogai", RequiresAuth: true}), nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	_, err := svc.ListSpots(context.Background(), SpotListQuery{Categories: []string{"Stogai"}})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestListSpots_NextCursor(t *testing.T) {
+	var calls []db.ListSpotsByNameParams
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByNameFunc: func(ctx context.Context, arg db.ListSpotsByNameParams) ([]db.ListSpotsByNameRow, error) {
+			calls = append(calls, arg)
+			if arg.MaxResults != 3 {
+				t.Fatalf("expected limit+1 rows to be requested, got %d", arg.MaxResults)
+			}
+			return []db.ListSpotsByNameRow{
+				{ID: 4, Name: "Ąžuolynas"},
+				{ID: 2, Name: "Beržynas"},
+				{ID: 9, Name: "Eglynas"},
+			}, nil
+		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			return 7, nil
 		},
 	}
 
 	svc := NewSpotService(mock)
 	ctx := context.Background()
 
-	category := db.SpotCategorySlaptosVietos
+	page, err := svc.ListSpots(ctx, SpotListQuery{Sort: SortByName, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextCursor == nil || page.Total != 7 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
 
-	_, err := svc.GetPublicSpotsByCategoryWithDetails(ctx, category)
-	if err == nil {
-		t.Fatal("expected error for secret category, got nil")
+	if _, err := svc.ListSpots(ctx, SpotListQuery{Sort: SortByName, Limit: 2, Cursor: *page.NextCursor}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	next := calls[1]
+	if !next.HasCursor || next.CursorID != 2 || next.CursorName != "Beržynas" {
+		t.Fatalf("cursor does not point after the last item: %+v", next)
 	}
 
-	expected := "secret category not accessible through public endpoint"
-	if err.Error() != expected {
-		t.Fatalf("unexpected error message: %v", err)
+	// A cursor is only valid for the sort order it was created with
+	_, err = svc.ListSpots(ctx, SpotListQuery{Sort: SortByNewest, Limit: 2, Cursor: *page.NextCursor})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestListSpots_NewestCursor(t *testing.T) {
+	created := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var calls []db.ListSpotsByNewestParams
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByNewestFunc: func(ctx context.Context, arg db.ListSpotsByNewestParams) ([]db.ListSpotsByNewestRow, error) {
+			calls = append(calls, arg)
+			return []db.ListSpotsByNewestRow{
+				{ID: 8, Name: "Naujas", CreatedAt: pgtype.Timestamp{Time: created, Valid: true}},
+				{ID: 5, Name: "Senas", CreatedAt: pgtype.Timestamp{Time: created.Add(-time.Hour), Valid: true}},
+			}, nil
+		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			return 2, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+	ctx := context.Background()
+
+	page, err := svc.ListSpots(ctx, SpotListQuery{Sort: SortByNewest, Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.NextCursor == nil {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+	if _, err := svc.ListSpots(ctx, SpotListQuery{Sort: SortByNewest, Limit: 1, Cursor: *page.NextCursor}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	next := calls[1]
+	if !next.HasCursor || next.CursorID != 8 || !next.CursorCreatedAt.Time.Equal(created) {
+		t.Fatalf("cursor does not point after the last item: %+v", next)
+	}
+}
+
+func TestCreateSpotWithLocation_Success(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+		InsertLocationFunc: func(ctx context.Context, arg db.InsertLocationParams) (db.Location, error) {
+			return db.Location{ID: 7, Address: arg.Address, Latitude: arg.Latitude, Longitude: arg.Longitude}, nil
+		},
+		InsertSpotFunc: func(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error) {
+			if arg.LocationID != 7 {
+				t.Fatalf("expected spot to point at location 7, got %d", arg.LocationID)
+			}
+			return db.Spot{ID: 3, Category: arg.Category, Name: arg.Name, Description: arg.Description, LocationID: arg.LocationID}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	spot, err := svc.CreateSpotWithLocation(context.Background(), SpotInput{
+		Category:    "Gamta",
+		Name:        "Pilaitės parkas",
+		Description: "Parkas",
+		Address:     "Pilaitės pr. 1",
+		Latitude:    54.7070,
+		Longitude:   25.1800,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spot.ID != 3 || spot.LocationID != 7 || spot.Address != "Pilaitės pr. 1" {
+		t.Fatalf("unexpected spot: %+v", spot)
+	}
+}
+
+func TestCreateSpotWithLocation_InvalidInput(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	coordinates := [][2]float64{{120, 25.1800}, {math.NaN(), 25.1800}, {54.7070, math.Inf(1)}}
+	for _, c := range coordinates {
+		_, err := svc.CreateSpotWithLocation(context.Background(), SpotInput{
+			Category:    "Gamta",
+			Name:        "Parkas",
+			Description: "Parkas",
+			Address:     "Pilaitės pr. 1",
+			Latitude:    c[0],
+			Longitude:   c[1],
+		})
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected validation error for %v, got %v", c, err)
+		}
+	}
+}
+
+func TestCreateSpotWithLocation_UnknownCategory(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+	}
+
+	svc := NewSpotService(mock)
+
+	_, err := svc.CreateSpotWithLocation(context.Background(), SpotInput{
+		Category:    "Muziejai",
+		Name:        "Muziejus",
+		Description: "Muziejus",
+		Address:     "Pilaitės pr. 1",
+		Latitude:    54.7070,
+		Longitude:   25.1800,
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestPatchSpotWithLocation_KeepsUnchangedFields(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		// Plain GetSpotByID is not set, the patch has to lock the row
+		GetSpotByIDForUpdateFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Gamta", Name: "Senas", Description: "Aprašymas", LocationID: 5}, nil
+		},
+		GetLocationByIDFunc: func(ctx context.Context, id int64) (db.Location, error) {
+			return db.Location{ID: id, Address: "Senas adresas", Latitude: 54.7, Longitude: 25.2}, nil
+		},
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+		DeleteSpotEquipmentBySpotIDFunc: func(ctx context.Context, spotID int64) error {
+			return nil
+		},
+		UpdateSpotFunc: func(ctx context.Context, arg db.UpdateSpotParams) (db.Spot, error) {
+			if arg.Category != "Gamta" || arg.Description != "Aprašymas" {
+				t.Fatalf("unchanged fields were overwritten: %+v", arg)
+			}
+			return db.Spot{ID: arg.ID, Category: arg.Category, Name: arg.Name, Description: arg.Description, LocationID: 5}, nil
+		},
+		UpdateLocationFunc: func(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error) {
+			if arg.Address != "Senas adresas" || arg.Latitude != 54.7 {
+				t.Fatalf("unchanged location fields were overwritten: %+v", arg)
+			}
+			return db.Location{ID: arg.ID, Address: arg.Address, Latitude: arg.Latitude, Longitude: arg.Longitude}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	name := "Naujas"
+	spot, err := svc.PatchSpotWithLocation(context.Background(), 1, SpotPatch{Name: &name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spot.Name != "Naujas" {
+		t.Fatalf("expected name 'Naujas', got %s", spot.Name)
+	}
+}
+
+func TestUpdateSpotWithLocation_RemovesEquipmentOutsideEquipmentCategories(t *testing.T) {
+	for category, wantDeleted := range map[string]bool{"Gamta": true, "Lauko_treniruokliai": false} {
+		deleted := false
+		mock := &mocks.MockSpotQueries{
+			GetCategoryBySlugFunc: seededCategoryBySlug,
+			UpdateSpotFunc: func(ctx context.Context, arg db.UpdateSpotParams) (db.Spot, error) {
+				return db.Spot{ID: arg.ID, Category: arg.Category, LocationID: 5}, nil
+			},
+			UpdateLocationFunc: func(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error) {
+				return db.Location{ID: arg.ID}, nil
+			},
+			DeleteSpotEquipmentBySpotIDFunc: func(ctx context.Context, spotID int64) error {
+				deleted = spotID == 1
+				return nil
+			},
+		}
+
+		svc := NewSpotService(mock)
+
+		_, err := svc.UpdateSpotWithLocation(context.Background(), 1, SpotInput{
+			Category:    category,
+			Name:        "Parkas",
+			Description: "Parkas",
+			Address:     "Pilaitės pr. 1",
+			Latitude:    54.7070,
+			Longitude:   25.1800,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if deleted != wantDeleted {
+			t.Fatalf("moving the spot to %s: expected equipment deleted=%v, got %v", category, wantDeleted, deleted)
+		}
+	}
+}
+
+func TestDeleteSpotWithLocation_RemovesImagesAndLocation(t *testing.T) {
+	imagesDeleted := false
+	deletedLocation := int64(0)
+
+	mock := &mocks.MockSpotQueries{
+		DeleteImagesBySpotIDFunc: func(ctx context.Context, spotID int64) ([]db.Image, error) {
+			imagesDeleted = true
+			return nil, nil
+		},
+		DeleteSpotFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, LocationID: 9}, nil
+		},
+		DeleteLocationFunc: func(ctx context.Context, id int64) error {
+			deletedLocation = id
+			return nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	if _, err := svc.DeleteSpotWithLocation(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !imagesDeleted {
+		t.Fatal("expected images to be deleted")
+	}
+	if deletedLocation != 9 {
+		t.Fatalf("expected location 9 to be deleted, got %d", deletedLocation)
+	}
+}
+
+func TestDeleteSpotWithLocation_NotFound(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		DeleteImagesBySpotIDFunc: func(ctx context.Context, spotID int64) ([]db.Image, error) {
+			return nil, nil
+		},
+		DeleteSpotFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{}, pgx.ErrNoRows
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	_, err := svc.DeleteSpotWithLocation(context.Background(), 1)
+	if !errors.Is(err, ErrSpotNotFound) {
+		t.Fatalf("expected ErrSpotNotFound, got %v", err)
+	}
+}
+
+func TestGetSpotsNearby_Success(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		GetSpotsNearbyFunc: func(ctx context.Context, arg db.GetSpotsNearbyParams) ([]db.GetSpotsNearbyRow, error) {
+			if arg.IncludeSecret {
+				t.Fatal("anonymous search must not include secret spots")
+			}
+			if arg.MinLat >= 54.7 || arg.MaxLat <= 54.7 || arg.MinLng >= 25.2 || arg.MaxLng <= 25.2 {
+				t.Fatalf("bounding box does not contain the center: %+v", arg)
+			}
+			return []db.GetSpotsNearbyRow{
+				{ID: 1, Name: "Parkas", Category: "Gamta", DistanceM: 120.5},
+			}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	spots, err := svc.GetSpotsNearby(context.Background(), 54.7, 25.2, 1000, false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spots) != 1 || spots[0].DistanceM == nil || *spots[0].DistanceM != 120.5 {
+		t.Fatalf("unexpected spots: %+v", spots)
+	}
+}
+
+func TestGetSpotsNearby_InvalidRadius(t *testing.T) {
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	_, err := svc.GetSpotsNearby(context.Background(), 54.7, 25.2, MaxNearbyRadiusM+1, true, 42)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestBoundingBox_ContainsRadius(t *testing.T) {
+	minLat, maxLat, minLng, maxLng := boundingBox(54.7, 25.2, 1000)
+
+	// One kilometer is roughly 0.009 degrees of latitude and 0.0156 degrees of longitude at 54.7N
+	if maxLat-54.7 < 0.0089 || maxLat-54.7 > 0.0091 {
+		t.Fatalf("unexpected latitude span: %f..%f", minLat, maxLat)
+	}
+	if maxLng-25.2 < 0.0154 || maxLng-25.2 > 0.0157 {
+		t.Fatalf("unexpected longitude span: %f..%f", minLng, maxLng)
+	}
+}
+
+func TestGetSpotsInBBox_ClustersLowZoom(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetSpotsInBBoxFunc: func(ctx context.Context, arg db.GetSpotsInBBoxParams) ([]db.GetSpotsInBBoxRow, error) {
+			t.Fatal("single spots should not be queried at low zoom")
+			return nil, nil
+		},
+		GetSpotClustersInBBoxFunc: func(ctx context.Context, arg db.GetSpotClustersInBBoxParams) ([]db.GetSpotClustersInBBoxRow, error) {
+			if arg.CellSize != clusterCellSize(10) || arg.MaxResults != maxClusterRows+1 {
+				t.Fatalf("unexpected cell size or limit: %f %d", arg.CellSize, arg.MaxResults)
+			}
+			return []db.GetSpotClustersInBBoxRow{
+				{CellY: 1, CellX: 1, Category: "Gamta", SpotCount: 3, AvgLatitude: 54.0, AvgLongitude: 25.0, SampleID: 4},
+				{CellY: 1, CellX: 1, Category: "Restoranai", SpotCount: 1, AvgLatitude: 54.4, AvgLongitude: 25.4, SampleID: 2},
+				{CellY: 1, CellX: 2, Category: "Gamta", SpotCount: 1, AvgLatitude: 54.5, AvgLongitude: 25.5, SampleID: 7},
+			}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	result, err := svc.GetSpotsInBBox(context.Background(), BBox{MinLat: 54, MinLng: 25, MaxLat: 55, MaxLng: 26}, 10, false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Clustered || len(result.Clusters) != 2 || result.Spots == nil {
+		t.Fatalf("expected 2 clusters and an empty spot list, got %+v", result)
+	}
+
+	first := result.Clusters[0]
+	if first.Count != 4 || first.Categories["Gamta"] != 3 || first.Categories["Restoranai"] != 1 {
+		t.Fatalf("unexpected first cluster: %+v", first)
+	}
+	if first.Latitude < 54.099 || first.Latitude > 54.101 || first.SpotID != nil {
+		t.Fatalf("unexpected first cluster centroid or spot id: %+v", first)
+	}
+
+	second := result.Clusters[1]
+	if second.Count != 1 || second.SpotID == nil || *second.SpotID != 7 {
+		t.Fatalf("single spot cluster should link the spot: %+v", second)
+	}
+}
+
+func TestTrimClusterRows_KeepsWholeCells(t *testing.T) {
+	rows := []db.GetSpotClustersInBBoxRow{
+		{CellY: 1, CellX: 1, Category: "Gamta"},
+		{CellY: 1, CellX: 2, Category: "Gamta"},
+		{CellY: 1, CellX: 2, Category: "Restoranai"},
+		{CellY: 1, CellX: 2, Category: "Sportas"},
+	}
+
+	if trimmed := trimClusterRows(rows, 4); len(trimmed) != 4 {
+		t.Fatalf("expected all rows under the limit, got %+v", trimmed)
+	}
+	// The limit cuts cell 1/2 after two of its three categories, so it is dropped
+	if trimmed := trimClusterRows(rows, 3); len(trimmed) != 1 || trimmed[0].CellX != 1 {
+		t.Fatalf("expected only the first cell, got %+v", trimmed)
+	}
+}
+
+func TestGetSpotsInBBox_SpotsHighZoom(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		GetSpotsInBBoxFunc: func(ctx context.Context, arg db.GetSpotsInBBoxParams) ([]db.GetSpotsInBBoxRow, error) {
+			if !arg.IncludeSecret {
+				t.Fatal("logged in request should include secret spots")
+			}
+			return []db.GetSpotsInBBoxRow{{ID: 1, Name: "Parkas", Category: "Gamta"}}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	result, err := svc.GetSpotsInBBox(context.Background(), BBox{MinLat: 54.7, MinLng: 25.2, MaxLat: 54.71, MaxLng: 25.21}, MinSpotZoom, true, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Clustered || len(result.Spots) != 1 || result.Spots[0].Name != "Parkas" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestGetSpotsInBBox_InvalidBBox(t *testing.T) {
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	_, err := svc.GetSpotsInBBox(context.Background(), BBox{MinLat: 55, MinLng: 25, MaxLat: 54, MaxLng: 26}, 10, false, 0)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestSearchSpots_EscapesSnippet(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		SearchSpotsFunc: func(ctx context.Context, arg db.SearchSpotsParams) ([]db.SearchSpotsRow, error) {
+			if arg.Query != "zalias" || arg.IncludeSecret {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			return []db.SearchSpotsRow{
+				{ID: 1, Name: "Žalias parkas", Category: "Gamta", Rank: 0.6, Snippet: "\x02Žalias\x03 parkas - <b>tylus</b>"},
+			}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	results, err := svc.SearchSpots(context.Background(), "  zalias ", false, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if want := "<mark>Žalias</mark> parkas - &lt;b&gt;tylus&lt;/b&gt;"; results[0].Snippet != want {
+		t.Fatalf("expected snippet %q, got %q", want, results[0].Snippet)
+	}
+}
+
+func TestSearchSpots_EmptyQuery(t *testing.T) {
+	svc := NewSpotService(&mocks.MockSpotQueries{})
+
+	_, err := svc.SearchSpots(context.Background(), "   ", true, 42)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestListSpots_MarksFavorites(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+			return []db.ListSpotsByIDRow{{ID: 1, Name: "Parkas"}, {ID: 2, Name: "Ežeras"}}, nil
+		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			return 2, nil
+		},
+		GetFavoriteSpotIDsFunc: func(ctx context.Context, arg db.GetFavoriteSpotIDsParams) ([]int64, error) {
+			if arg.UserID != 42 || len(arg.SpotIds) != 2 {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			return []int64{2}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	page, err := svc.ListSpots(context.Background(), SpotListQuery{UserID: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, second := page.Items[0].IsFavorite, page.Items[1].IsFavorite
+	if first == nil || *first || second == nil || !*second {
+		t.Fatalf("expected only the second spot to be a favorite, got %v and %v", first, second)
+	}
+}
+
+func TestListSpots_AnonymousHasNoFavoriteFlag(t *testing.T) {
+	// GetFavoriteSpotIDsFunc is not set, so looking up favorites would panic
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		ListSpotsByIDFunc: func(ctx context.Context, arg db.ListSpotsByIDParams) ([]db.ListSpotsByIDRow, error) {
+			return []db.ListSpotsByIDRow{{ID: 1, Name: "Parkas"}}, nil
+		},
+		CountSpotsFunc: func(ctx context.Context, arg db.CountSpotsParams) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	page, err := svc.ListSpots(context.Background(), SpotListQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Items[0].IsFavorite != nil {
+		t.Fatal("anonymous list SHOULD NOT carry is_favorite")
+	}
+}
+
+func TestAddFavorite_SecretSpot(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoryBySlugFunc: seededCategoryBySlug,
+		GetSpotByIDFunc: func(ctx context.Context, id int64) (db.Spot, error) {
+			return db.Spot{ID: id, Category: "Slaptos_vietos"}, nil
+		},
+		AddFavoriteFunc: func(ctx context.Context, arg db.AddFavoriteParams) error {
+			if arg.UserID != 42 || arg.SpotID != 3 {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			return nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	// Favorites belong to logged in users, who see secret spots
+	if err := svc.AddFavorite(context.Background(), 42, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetFavoriteSpots_MarksFavorites(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		GetFavoriteSpotsFunc: func(ctx context.Context, userID int64) ([]db.GetFavoriteSpotsRow, error) {
+			if userID != 42 {
+				t.Fatalf("unexpected user: %d", userID)
+			}
+			return []db.GetFavoriteSpotsRow{{ID: 1, Name: "Parkas"}}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	spots, err := svc.GetFavoriteSpots(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spots) != 1 || spots[0].IsFavorite == nil || !*spots[0].IsFavorite {
+		t.Fatalf("unexpected favorites: %+v", spots)
+	}
+}
+
+func TestGetSpotsNearby_MarksFavorites(t *testing.T) {
+	mock := &mocks.MockSpotQueries{
+		GetCategoriesFunc: listSeededCategories,
+		GetSpotsNearbyFunc: func(ctx context.Context, arg db.GetSpotsNearbyParams) ([]db.GetSpotsNearbyRow, error) {
+			return []db.GetSpotsNearbyRow{{ID: 1, Name: "Parkas"}, {ID: 2, Name: "Ąžuolas"}}, nil
+		},
+		GetFavoriteSpotIDsFunc: func(ctx context.Context, arg db.GetFavoriteSpotIDsParams) ([]int64, error) {
+			if arg.UserID != 42 {
+				t.Fatalf("unexpected params: %+v", arg)
+			}
+			return []int64{1}, nil
+		},
+	}
+
+	svc := NewSpotService(mock)
+
+	spots, err := svc.GetSpotsNearby(context.Background(), 54.7, 25.2, 1000, true, 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, second := spots[0].IsFavorite, spots[1].IsFavorite
+	if first == nil || !*first || second == nil || *second {
+		t.Fatalf("expected only the first spot to be a favorite, got %v and %v", first, second)
 	}
 }
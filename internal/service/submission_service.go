@@ -0,0 +1,358 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/dto"
+	"PilaiteProject/internal/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// MaxSubmissionImages limits the photos a user can attach to one proposal
+	MaxSubmissionImages = 5
+
+	maxRejectionReasonLength = 500
+)
+
+type SubmissionService struct {
+	queries interfaces.SubmissionQueries
+	images  *ImageService
+}
+
+func NewSubmissionService(queries interfaces.SubmissionQueries, images *ImageService) *SubmissionService {
+	return &SubmissionService{queries: queries, images: images}
+}
+
+// CreateSubmission stores a user's spot proposal as pending, it needs an admin to become a spot
+func (s *SubmissionService) CreateSubmission(ctx context.Context, userID int64, in SpotInput) (*dto.SubmissionDTO, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	if _, err := checkCategory(ctx, s.queries, in.Category); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.InsertSubmission(ctx, db.InsertSubmissionParams{
+		UserID:      userID,
+		Category:    in.Category,
+		Name:        in.Name,
+		Description: in.Description,
+		Address:     in.Address,
+		Latitude:    in.Latitude,
+		Longitude:   in.Longitude,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert submission: %w", err)
+	}
+	return toSubmissionDTO(row, nil), nil
+}
+
+// UploadSubmissionImage attaches a photo to the user's own pending submission. The
+// checks are repeated with the submission row locked, so parallel uploads can't pass
+// the image limit and an upload racing with a review doesn't leave an orphan behind.
+func (s *SubmissionService) UploadSubmissionImage(ctx context.Context, submissionID, userID int64, contentType string, data []byte) (*dto.SubmissionImageDTO, error) {
+	// Checked before the image is processed, the transaction below is the one that counts
+	submission, err := s.getSubmission(ctx, submissionID)
+	// Other users' submissions are reported as missing
+	if err != nil || submission.UserID != userID {
+		if err == nil {
+			err = ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	if submission.Status != db.SubmissionStatusPending {
+		return nil, ErrSubmissionNotPending
+	}
+
+	stored, err := s.images.StoreUpload(contentType, data, fmt.Sprintf("submissions/%d", submissionID))
+	if err != nil {
+		return nil, err
+	}
+
+	var row db.SubmissionImage
+	err = s.queries.ExecSubmissionTx(ctx, func(q interfaces.SubmissionQueries) error {
+		if _, err := lockPendingSubmission(ctx, q, submissionID); err != nil {
+			return err
+		}
+
+		existing, err := q.GetSubmissionImages(ctx, submissionID)
+		if err != nil {
+			return fmt.Errorf("failed to get submission images: %w", err)
+		}
+		if len(existing) >= MaxSubmissionImages {
+			return newValidationError("a submission can have at most %d images", MaxSubmissionImages)
+		}
+
+		row, err = q.InsertSubmissionImage(ctx, db.InsertSubmissionImageParams{
+			SubmissionID:     submissionID,
+			Url:              stored.URL,
+			ThumbnailUrl:     stored.ThumbnailURL,
+			OriginalMetadata: stored.OriginalMetadata,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert submission image: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.images.DeleteStoredImage(stored)
+		return nil, err
+	}
+	return toSubmissionImageDTO(row), nil
+}
+
+// GetUserSubmissions lets users follow their own proposals, newest first
+func (s *SubmissionService) GetUserSubmissions(ctx context.Context, userID int64) ([]dto.SubmissionDTO, error) {
+	rows, err := s.queries.GetSubmissionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+	return toSubmissionDTOs(rows), nil
+}
+
+// GetSubmissionsByStatus is the admin moderation queue, oldest first
+func (s *SubmissionService) GetSubmissionsByStatus(ctx context.Context, status db.SubmissionStatus) ([]dto.SubmissionDTO, error) {
+	if !status.Valid() {
+		return nil, newValidationError("invalid status: %v", status)
+	}
+
+	rows, err := s.queries.GetSubmissionsByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+	return toSubmissionDTOs(rows), nil
+}
+
+// GetSubmission returns a submission together with its photos
+func (s *SubmissionService) GetSubmission(ctx context.Context, id int64) (*dto.SubmissionDTO, error) {
+	submission, err := s.getSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := s.queries.GetSubmissionImages(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission images: %w", err)
+	}
+	return toSubmissionDTO(submission, images), nil
+}
+
+// UpdateSubmission lets an admin fix a pending submission before approving it
+func (s *SubmissionService) UpdateSubmission(ctx context.Context, id int64, in SpotInput) (*dto.SubmissionDTO, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+	if _, err := checkCategory(ctx, s.queries, in.Category); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpdateSubmission(ctx, db.UpdateSubmissionParams{
+		ID:          id,
+		Category:    in.Category,
+		Name:        in.Name,
+		Description: in.Description,
+		Address:     in.Address,
+		Latitude:    in.Latitude,
+		Longitude:   in.Longitude,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.notPendingError(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update submission: %w", err)
+	}
+	return toSubmissionDTO(row, nil), nil
+}
+
+// ApproveSubmission creates the location and spot of a pending submission and moves its
+// photos to the new spot. Everything happens in one transaction, so a failure leaves
+// the submission pending and no half created spot behind.
+func (s *SubmissionService) ApproveSubmission(ctx context.Context, id, adminID int64) (*dto.SubmissionDTO, error) {
+	var approved db.SpotSubmission
+	err := s.queries.ExecSubmissionTx(ctx, func(q interfaces.SubmissionQueries) error {
+		submission, err := lockPendingSubmission(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		// The same rules as for spots created by admins directly
+		in := SpotInput{
+			Category:    submission.Category,
+			Name:        submission.Name,
+			Description: submission.Description,
+			Address:     submission.Address,
+			Latitude:    submission.Latitude,
+			Longitude:   submission.Longitude,
+		}
+		if err := in.validate(); err != nil {
+			return err
+		}
+
+		location, err := q.InsertLocation(ctx, db.InsertLocationParams{
+			Address:   in.Address,
+			Latitude:  in.Latitude,
+			Longitude: in.Longitude,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert location: %w", err)
+		}
+
+		spot, err := q.InsertSpot(ctx, db.InsertSpotParams{
+			Category:    in.Category,
+			Name:        in.Name,
+			Description: in.Description,
+			LocationID:  location.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert spot: %w", err)
+		}
+
+		if err := q.MoveSubmissionImagesToSpot(ctx, db.MoveSubmissionImagesToSpotParams{
+			SpotID:       spot.ID,
+			SubmissionID: id,
+		}); err != nil {
+			return fmt.Errorf("failed to move submission images: %w", err)
+		}
+
+		approved, err = q.ApproveSubmission(ctx, db.ApproveSubmissionParams{
+			ID:         id,
+			SpotID:     pgtype.Int8{Int64: spot.ID, Valid: true},
+			ReviewedBy: pgtype.Int8{Int64: adminID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to approve submission: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSubmissionDTO(approved, nil), nil
+}
+
+// RejectSubmission closes a pending submission with a reason the user can read.
+// The photos are deleted, they were never moderated and must not stay online.
+func (s *SubmissionService) RejectSubmission(ctx context.Context, id, adminID int64, reason string) (*dto.SubmissionDTO, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, newValidationError("rejection reason cannot be empty")
+	}
+	if utf8.RuneCountInString(reason) > maxRejectionReasonLength {
+		return nil, newValidationError("rejection reason cannot be longer than %d characters", maxRejectionReasonLength)
+	}
+
+	var rejected db.SpotSubmission
+	var images []db.SubmissionImage
+	err := s.queries.ExecSubmissionTx(ctx, func(q interfaces.SubmissionQueries) error {
+		if _, err := lockPendingSubmission(ctx, q, id); err != nil {
+			return err
+		}
+
+		var err error
+		rejected, err = q.RejectSubmission(ctx, db.RejectSubmissionParams{
+			ID:              id,
+			RejectionReason: reason,
+			ReviewedBy:      pgtype.Int8{Int64: adminID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reject submission: %w", err)
+		}
+
+		images, err = q.DeleteSubmissionImages(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete submission images: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are only removed once the rows are gone for good
+	for _, image := range images {
+		s.images.DeleteStoredImage(&StoredImage{URL: image.Url, ThumbnailURL: image.ThumbnailUrl})
+	}
+	return toSubmissionDTO(rejected, nil), nil
+}
+
+func (s *SubmissionService) getSubmission(ctx context.Context, id int64) (db.SpotSubmission, error) {
+	submission, err := s.queries.GetSubmissionByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.SpotSubmission{}, ErrSubmissionNotFound
+	}
+	if err != nil {
+		return db.SpotSubmission{}, fmt.Errorf("failed to get submission: %w", err)
+	}
+	return submission, nil
+}
+
+// notPendingError explains why a pending-only update matched no rows
+func (s *SubmissionService) notPendingError(ctx context.Context, id int64) error {
+	if _, err := s.getSubmission(ctx, id); err != nil {
+		return err
+	}
+	return ErrSubmissionNotPending
+}
+
+// lockPendingSubmission locks the submission row for the rest of the transaction
+func lockPendingSubmission(ctx context.Context, q interfaces.SubmissionQueries, id int64) (db.SpotSubmission, error) {
+	submission, err := q.GetSubmissionByIDForUpdate(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.SpotSubmission{}, ErrSubmissionNotFound
+	}
+	if err != nil {
+		return db.SpotSubmission{}, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission.Status != db.SubmissionStatusPending {
+		return db.SpotSubmission{}, ErrSubmissionNotPending
+	}
+	return submission, nil
+}
+
+func toSubmissionDTOs(rows []db.SpotSubmission) []dto.SubmissionDTO {
+	dtos := make([]dto.SubmissionDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = *toSubmissionDTO(row, nil)
+	}
+	return dtos
+}
+
+func toSubmissionDTO(row db.SpotSubmission, images []db.SubmissionImage) *dto.SubmissionDTO {
+	submission := &dto.SubmissionDTO{
+		ID:              row.ID,
+		UserID:          row.UserID,
+		Category:        row.Category,
+		Name:            row.Name,
+		Description:     row.Description,
+		Address:         row.Address,
+		Latitude:        row.Latitude,
+		Longitude:       row.Longitude,
+		Status:          string(row.Status),
+		RejectionReason: row.RejectionReason,
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}
+	if row.SpotID.Valid {
+		spotID := row.SpotID.Int64
+		submission.SpotID = &spotID
+	}
+	for _, image := range images {
+		submission.Images = append(submission.Images, *toSubmissionImageDTO(image))
+	}
+	return submission
+}
+
+func toSubmissionImageDTO(row db.SubmissionImage) *dto.SubmissionImageDTO {
+	return &dto.SubmissionImageDTO{
+		ID:           row.ID,
+		URL:          row.Url,
+		ThumbnailURL: row.ThumbnailUrl,
+	}
+}
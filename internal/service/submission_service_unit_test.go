@@ -0,0 +1,232 @@
+package service
+
+import (
+	"PilaiteProject/internal/db"
+	"PilaiteProject/internal/mocks"
+	"context"
+	"errors"
+	"testing"
+)
+
+func pendingSubmission(id int64) db.SpotSubmission {
+	return db.SpotSubmission{
+		ID:          id,
+		UserID:      42,
+		Category:    "Gamta",
+		Name:        "Pilaitės ąžuolas",
+		Description: "Senas ąžuolas prie tvenkinio",
+		Address:     "Pilaitės pr. 1",
+		Latitude:    54.7,
+		Longitude:   25.2,
+		Status:      db.SubmissionStatusPending,
+	}
+}
+
+func TestCreateSubmission_InvalidInput(t *testing.T) {
+	// No query funcs are set, so touching the database would panic
+	svc := NewSubmissionService(&mocks.MockSubmissionQueries{}, nil)
+
+	_, err := svc.CreateSubmission(context.Background(), 42, SpotInput{
+		Category:    "Gamta",
+		Name:        "",
+		Description: "Senas ąžuolas prie tvenkinio",
+		Address:     "Pilaitės pr. 1",
+		Latitude:    54.7,
+		Longitude:   25.2,
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestApproveSubmission_CreatesSpot(t *testing.T) {
+	var moved bool
+	mock := &mocks.MockSubmissionQueries{
+		GetSubmissionByIDForUpdateFunc: func(ctx context.Context, id int64) (db.SpotSubmission, error) {
+			return pendingSubmission(id), nil
+		},
+		InsertLocationFunc: func(ctx context.Context, arg db.InsertLocationParams) (db.Location, error) {
+			if arg.Address != "Pilaitės pr. 1" || arg.Latitude != 54.7 {
+				t.Fatalf("unexpected location: %+v", arg)
+			}
+			return db.Location{ID: 10}, nil
+		},
+		InsertSpotFunc: func(ctx context.Context, arg db.InsertSpotParams) (db.Spot, error) {
+			if arg.LocationID != 10 || arg.Name != "Pilaitės ąžuolas" {
+				t.Fatalf("unexpected spot: %+v", arg)
+			}
+			return db.Spot{ID: 20}, nil
+		},
+		MoveSubmissionImagesToSpotFunc: func(ctx context.Context, arg db.MoveSubmissionImagesToSpotParams) error {
+			if arg.SpotID != 20 || arg.SubmissionID != 5 {
+				t.Fatalf("unexpected image move: %+v", arg)
+			}
+			moved = true
+			return nil
+		},
+		ApproveSubmissionFunc: func(ctx context.Context, arg db.ApproveSubmissionParams) (db.SpotSubmission, error) {
+			if arg.SpotID.Int64 != 20 || arg.ReviewedBy.Int64 != 1 {
+				t.Fatalf("unexpected approval: %+v", arg)
+			}
+			row := pendingSubmission(arg.ID)
+			row.Status = db.SubmissionStatusApproved
+			row.SpotID = arg.SpotID
+			return row, nil
+		},
+	}
+
+	svc := NewSubmissionService(mock, nil)
+
+	submission, err := svc.ApproveSubmission(context.Background(), 5, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !moved {
+		t.Fatalf("expected submission images to be moved to the spot")
+	}
+	if submission.Status != "approved" || submission.SpotID == nil || *submission.SpotID != 20 {
+		t.Fatalf("unexpected submission: %+v", submission)
+	}
+}
+
+func TestApproveSubmission_NotPending(t *testing.T) {
+	mock := &mocks.MockSubmissionQueries{
+		GetSubmissionByIDForUpdateFunc: func(ctx context.Context, id int64) (db.SpotSubmission, error) {
+			row := pendingSubmission(id)
+			row.Status = db.SubmissionStatusRejected
+			return row, nil
+		},
+	}
+
+	svc := NewSubmissionService(mock, nil)
+
+	_, err := svc.ApproveSubmission(context.Background(), 5, 1)
+	if !errors.Is(err, ErrSubmissionNotPending) {
+		t.Fatalf("expected ErrSubmissionNotPending, got %v", err)
+	}
+}
+
+func TestRejectSubmission_RequiresReason(t *testing.T) {
+	svc := NewSubmissionService(&mocks.MockSubmissionQueries{}, nil)
+
+	_, err := svc.RejectSubmission(context.Background(), 5, 1, "   ")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestRejectSubmission_RemovesImageFiles(t *testing.T) {
+	files := mocks.NewMockFileStorage()
+	files.Files["/uploads/submissions/5/a_display.png"] = []byte("display")
+	files.Files["/uploads/submissions/5/a_thumb.png"] = []byte("thumb")
+
+	mock := &mocks.MockSubmissionQueries{
+		GetSubmissionByIDForUpdateFunc: func(ctx context.Context, id int64) (db.SpotSubmission, error) {
+			return pendingSubmission(id), nil
+		},
+		RejectSubmissionFunc: func(ctx context.Context, arg db.RejectSubmissionParams) (db.SpotSubmission, error) {
+			if arg.RejectionReason != "Duplicate" {
+				t.Fatalf("expected trimmed reason, got %q", arg.RejectionReason)
+			}
+			row := pendingSubmission(arg.ID)
+			row.Status = db.SubmissionStatusRejected
+			row.RejectionReason = arg.RejectionReason
+			return row, nil
+		},
+		DeleteSubmissionImagesFunc: func(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error) {
+			return []db.SubmissionImage{{
+				ID:           1,
+				SubmissionID: submissionID,
+				Url:          "/uploads/submissions/5/a_display.png",
+				ThumbnailUrl: "/uploads/submissions/5/a_thumb.png",
+			}}, nil
+		},
+	}
+
+	svc := NewSubmissionService(mock, NewImageService(&mocks.MockImageQueries{}, files, false))
+
+	submission, err := svc.RejectSubmission(context.Background(), 5, 1, " Duplicate ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if submission.RejectionReason != "Duplicate" {
+		t.Fatalf("unexpected submission: %+v", submission)
+	}
+	if len(files.Files) != 0 {
+		t.Fatalf("expected image files to be removed, got %v", files.Files)
+	}
+}
+
+func TestUploadSubmissionImage_OtherUser(t *testing.T) {
+	mock := &mocks.MockSubmissionQueries{
+		GetSubmissionByIDFunc: func(ctx context.Context, id int64) (db.SpotSubmission, error) {
+			return pendingSubmission(id), nil
+		},
+	}
+
+	svc := NewSubmissionService(mock, nil)
+
+	_, err := svc.UploadSubmissionImage(context.Background(), 5, 7, "image/png", testPNG(t, 10, 10))
+	if !errors.Is(err, ErrSubmissionNotFound) {
+		t.Fatalf("expected ErrSubmissionNotFound, got %v", err)
+	}
+}
+
+func TestUploadSubmissionImage_RecheckedUnderLock(t *testing.T) {
+	tests := []struct {
+		name     string
+		locked   db.SpotSubmission
+		existing int
+		want     func(error) bool
+	}{
+		{
+			name:     "limit reached by a parallel upload",
+			locked:   pendingSubmission(5),
+			existing: MaxSubmissionImages,
+			want: func(err error) bool {
+				var validationErr *ValidationError
+				return errors.As(err, &validationErr)
+			},
+		},
+		{
+			name: "approved in the meantime",
+			locked: func() db.SpotSubmission {
+				row := pendingSubmission(5)
+				row.Status = db.SubmissionStatusApproved
+				return row
+			}(),
+			want: func(err error) bool { return errors.Is(err, ErrSubmissionNotPending) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mocks.MockSubmissionQueries{
+				// The unlocked read still sees a pending submission with room for photos
+				GetSubmissionByIDFunc: func(ctx context.Context, id int64) (db.SpotSubmission, error) {
+					return pendingSubmission(id), nil
+				},
+				GetSubmissionByIDForUpdateFunc: func(ctx context.Context, id int64) (db.SpotSubmission, error) {
+					return tt.locked, nil
+				},
+				GetSubmissionImagesFunc: func(ctx context.Context, submissionID int64) ([]db.SubmissionImage, error) {
+					return make([]db.SubmissionImage, tt.existing), nil
+				},
+			}
+			files := mocks.NewMockFileStorage()
+			svc := NewSubmissionService(mock, NewImageService(&mocks.MockImageQueries{}, files, false))
+
+			_, err := svc.UploadSubmissionImage(context.Background(), 5, 42, "image/png", testPNG(t, 10, 10))
+			if !tt.want(err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(files.Files) != 0 {
+				t.Fatalf("expected the stored files to be removed, got %d", len(files.Files))
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"PilaiteProject/internal/db"
 	"PilaiteProject/internal/interfaces"
 	"context"
+	"errors"
 	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService struct {
@@ -63,6 +67,47 @@ func (s *UserService) InsertUser(ctx context.Context, email, password string, ro
 	return &user, nil
 }
 
+// CreateAdmin adds an administrator account. Registration only ever creates plain users,
+// so this is the way to get the first admin. An email that is already registered is
+// refused with ErrUserExists, PromoteAdmin is the explicit way to make it an admin.
+func (s *UserService) CreateAdmin(ctx context.Context, email, password string) (*db.User, error) {
+	if email == "" {
+		return nil, newValidationError("email cannot be empty")
+	}
+	if err := ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
+	_, err := s.queries.GetUserByEmail(ctx, email)
+	if err == nil {
+		return nil, ErrUserExists
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.InsertUser(ctx, email, string(hash), db.UserRoleAdmin)
+}
+
+// PromoteAdmin makes an existing user an administrator, their password stays as it is
+func (s *UserService) PromoteAdmin(ctx context.Context, email string) (*db.User, error) {
+	if email == "" {
+		return nil, newValidationError("email cannot be empty")
+	}
+	user, err := s.queries.PromoteUserToAdmin(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote user: %w", err)
+	}
+	return &user, nil
+}
+
 func (s *UserService) StringToUserRole(roleStr string) (db.UserRole, error) {
 	role := db.UserRole(roleStr)
 	if !role.Valid() {
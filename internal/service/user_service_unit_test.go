@@ -4,7 +4,11 @@ import (
 	"PilaiteProject/internal/db"
 	"PilaiteProject/internal/mocks"
 	"context"
+	"errors"
 	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestInsertUser_EmptyEmail(t *testing.T) {
@@ -147,3 +151,90 @@ func TestStringToUserRole_Invalid(t *testing.T) {
 		t.Fatal("expected error for invalid role")
 	}
 }
+
+func TestValidatePassword(t *testing.T) {
+	for _, password := range []string{"Short1", "nouppercase1", "NoDigitsHere"} {
+		var validationErr *ValidationError
+		if err := ValidatePassword(password); !errors.As(err, &validationErr) {
+			t.Fatalf("expected %q to be rejected, got %v", password, err)
+		}
+	}
+	if err := ValidatePassword("Slaptazodis1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateAdmin_NewUser(t *testing.T) {
+	mock := &mocks.MockUserQueries{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{}, pgx.ErrNoRows
+		},
+		InsertUserFunc: func(ctx context.Context, arg db.InsertUserParams) (db.User, error) {
+			if arg.Role != db.UserRoleAdmin {
+				t.Fatalf("expected an admin, got %s", arg.Role)
+			}
+			if bcrypt.CompareHashAndPassword([]byte(arg.Password), []byte("Slaptazodis1")) != nil {
+				t.Fatalf("expected a bcrypt hash of the password")
+			}
+			return db.User{ID: 1, Email: arg.Email, Role: arg.Role}, nil
+		},
+	}
+
+	s := NewUserService(mock)
+
+	user, err := s.CreateAdmin(context.Background(), "admin@example.com", "Slaptazodis1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Role != db.UserRoleAdmin {
+		t.Fatalf("expected a new admin, got %+v", user)
+	}
+}
+
+func TestCreateAdmin_ExistingEmail(t *testing.T) {
+	// Neither InsertUserFunc nor PromoteUserToAdminFunc is set, touching the user would panic
+	mock := &mocks.MockUserQueries{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (db.User, error) {
+			return db.User{ID: 5, Email: email, Role: db.UserRoleUser}, nil
+		},
+	}
+
+	s := NewUserService(mock)
+
+	_, err := s.CreateAdmin(context.Background(), "user@example.com", "Slaptazodis1")
+	if !errors.Is(err, ErrUserExists) {
+		t.Fatalf("expected ErrUserExists, got %v", err)
+	}
+}
+
+func TestPromoteAdmin(t *testing.T) {
+	mock := &mocks.MockUserQueries{
+		PromoteUserToAdminFunc: func(ctx context.Context, email string) (db.User, error) {
+			if email == "missing@example.com" {
+				return db.User{}, pgx.ErrNoRows
+			}
+			return db.User{ID: 5, Email: email, Role: db.UserRoleAdmin}, nil
+		},
+	}
+
+	s := NewUserService(mock)
+
+	user, err := s.PromoteAdmin(context.Background(), "user@example.com")
+	if err != nil || user.ID != 5 || user.Role != db.UserRoleAdmin {
+		t.Fatalf("expected user 5 to be promoted, got %+v %v", user, err)
+	}
+	if _, err := s.PromoteAdmin(context.Background(), "missing@example.com"); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestCreateAdmin_WeakPassword(t *testing.T) {
+	s := NewUserService(&mocks.MockUserQueries{})
+
+	_, err := s.CreateAdmin(context.Background(), "admin@example.com", "admin")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minPasswordLength is the shortest password an account may have
+const minPasswordLength = 8
+
+// Column sizes from the location and spot migrations
+const (
+	maxAddressLength     = 30
+	maxSpotNameLength    = 50
+	maxDescriptionLength = 255
+)
+
+// validateSpotFields checks the field values, whether the category exists is up to checkCategory
+func validateSpotFields(category, name, description string) error {
+	if category == "" {
+		return newValidationError("category cannot be empty")
+	}
+	if name == "" {
+		return newValidationError("name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > maxSpotNameLength {
+		return newValidationError("name cannot be longer than %d characters", maxSpotNameLength)
+	}
+	if description == "" {
+		return newValidationError("description cannot be empty")
+	}
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return newValidationError("description cannot be longer than %d characters", maxDescriptionLength)
+	}
+	return nil
+}
+
+func validateLocationFields(address string, latitude, longitude float64) error {
+	if address == "" {
+		return newValidationError("address cannot be empty")
+	}
+	if utf8.RuneCountInString(address) > maxAddressLength {
+		return newValidationError("address cannot be longer than %d characters", maxAddressLength)
+	}
+	// Validate latitude range (-90 to 90), NaN fails every comparison so it is checked first
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return newValidationError("latitude must be between -90 and 90, got: %f", latitude)
+	}
+	// Validate longitude range (-180 to 180)
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return newValidationError("longitude must be between -180 and 180, got: %f", longitude)
+	}
+	return nil
+}
+
+// ValidatePassword checks the password rules shared by registration and create-admin
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return newValidationError("Password must be at least 8 characters")
+	}
+	hasUpper, hasDigit := false, false
+	for _, char := range password {
+		hasUpper = hasUpper || unicode.IsUpper(char)
+		hasDigit = hasDigit || unicode.IsDigit(char)
+	}
+	if !hasUpper {
+		return newValidationError("Password must have at least one uppercase letter")
+	}
+	if !hasDigit {
+		return newValidationError("Password must have at least one digit")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage keeps uploaded files in a directory on disk that the server exposes under urlPrefix
+type LocalStorage struct {
+	dir       string
+	urlPrefix string
+}
+
+func NewLocalStorage(dir, urlPrefix string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	return &LocalStorage{
+		dir:       dir,
+		urlPrefix: strings.TrimSuffix(urlPrefix, "/") + "/",
+	}, nil
+}
+
+// Save writes data under name and returns the URL the file is served from
+func (s *LocalStorage) Save(name string, data []byte) (string, error) {
+	cleanName, fullPath, err := s.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Write to a temporary file first so a half written file is never served
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return "", fmt.Errorf("failed to store file: %w", err)
+	}
+
+	return s.urlPrefix + cleanName, nil
+}
+
+// Delete removes the file behind a URL returned by Save.
+// URLs that point somewhere else (e.g. images added by hand) are ignored.
+func (s *LocalStorage) Delete(url string) error {
+	name, ok := strings.CutPrefix(url, s.urlPrefix)
+	if !ok {
+		return nil
+	}
+
+	_, fullPath, err := s.resolve(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// resolve turns a slash separated name into a path inside the storage directory
+func (s *LocalStorage) resolve(name string) (string, string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if cleaned == "" || strings.Contains(name, "..") {
+		return "", "", fmt.Errorf("invalid file name: %q", name)
+	}
+	return cleaned, filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir, "/uploads/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	url, err := s.Save("spots/1/photo.jpg", []byte("data"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url != "/uploads/spots/1/photo.jpg" {
+		t.Fatalf("unexpected url: %s", url)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "spots", "1", "photo.jpg")); err != nil {
+		t.Fatalf("file was not written: %v", err)
+	}
+
+	if err := s.Delete(url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "spots", "1", "photo.jpg")); !os.IsNotExist(err) {
+		t.Fatalf("file was not deleted: %v", err)
+	}
+}
+
+func TestSave_RejectsPathTraversal(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir(), "/uploads/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := s.Save("../outside.jpg", []byte("data")); err == nil {
+		t.Fatal("expected error for path traversal, got nil")
+	}
+	if err := s.Delete("/uploads/../../etc/passwd"); err == nil {
+		t.Fatal("expected error for path traversal, got nil")
+	}
+}
+
+func TestDelete_IgnoresForeignURL(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir(), "/uploads/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.Delete("https://example.com/beach.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -3,17 +3,53 @@ package wrapper
 import (
 	"PilaiteProject/internal/db"
 	"PilaiteProject/internal/interfaces"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type AppQueries struct {
 	*db.Queries
+	pool *pgxpool.Pool
 }
 
 // Verify that AppQueries implements the interfaces
 var (
-	_ interfaces.SpotQueries = (*AppQueries)(nil)
+	_ interfaces.SpotQueries       = (*AppQueries)(nil)
+	_ interfaces.ImageQueries      = (*AppQueries)(nil)
+	_ interfaces.ReviewQueries     = (*AppQueries)(nil)
+	_ interfaces.SubmissionQueries = (*AppQueries)(nil)
+	_ interfaces.CategoryQueries   = (*AppQueries)(nil)
 )
 
-func NewAppQueries(q *db.Queries) *AppQueries {
-	return &AppQueries{Queries: q}
+func NewAppQueries(pool *pgxpool.Pool) *AppQueries {
+	return &AppQueries{Queries: db.New(pool), pool: pool}
+}
+
+// ExecTx runs fn inside a single transaction, rolling back if fn returns an error
+func (q *AppQueries) ExecTx(ctx context.Context, fn func(interfaces.SpotQueries) error) error {
+	return q.execTx(ctx, func(tx *AppQueries) error { return fn(tx) })
+}
+
+// ExecSubmissionTx is ExecTx for the submission queries
+func (q *AppQueries) ExecSubmissionTx(ctx context.Context, fn func(interfaces.SubmissionQueries) error) error {
+	return q.execTx(ctx, func(tx *AppQueries) error { return fn(tx) })
+}
+
+func (q *AppQueries) execTx(ctx context.Context, fn func(*AppQueries) error) error {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&AppQueries{Queries: q.Queries.WithTx(tx), pool: q.pool}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
 }
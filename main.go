@@ -1,54 +1,111 @@
 package main
 
 import (
+	"PilaiteProject/internal/config"
 	"PilaiteProject/internal/dbConfig"
-	_ "PilaiteProject/internal/handler"
-	"PilaiteProject/internal/server"
+	"PilaiteProject/internal/logging"
 	"context"
-	"log"
-	"net/http"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
+	"path/filepath"
 
 	_ "github.com/jackc/pgx/v5"
 	_ "github.com/lib/pq"
 )
 
+// command is one subcommand of the binary. They all get the same config and database
+// connection, set up once in run.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, app *app, args []string) error
+}
+
+// app is what a command works with
+type app struct {
+	config config.Config
+	conn   *dbConfig.Connection
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+var commands = []command{
+	{name: "serve", usage: "serve [-migrate]", run: runServe},
+	{name: "migrate", usage: "migrate up [N] | down [N|all] | status | force VERSION", run: runMigrate},
+	{name: "seed", usage: "seed", run: runSeed},
+	{name: "create-admin", usage: "create-admin --email EMAIL < password | --promote --email EMAIL", run: runCreateAdmin},
+	{name: "import", usage: "import [-format csv|geojson] [-dry-run] FILE", run: runImport},
+}
+
+var errUsage = errors.New("unknown command")
+
 func main() {
-	ctx := context.Background()
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			printUsage(os.Stderr)
+			os.Exit(2)
+		}
+		if errors.Is(err, config.ErrInvalid) {
+			// Plain text, the logger isn't set up without a valid config
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		slog.Error("command failed", "error", err)
+		os.Exit(1)
+	}
+}
 
-	conn, err := dbConfig.ConnectDb(ctx)
+// run starts the named command, without one the server is started
+func run(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "help" {
+		printUsage(os.Stdout)
+		return nil
+	}
+
+	cfg, args, err := config.Load(args, os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage(os.Stdout)
+		return nil
+	}
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer conn.Pool.Close()
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
 
-	serverConfig := server.ServerConfig{
-		Host: "localhost",
-		Port: "8080",
+	name := "serve"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
 
-	server := server.NewServer(serverConfig, conn)
-	go func() {
-		if err := server.Start(); err != nil && err != http.ErrServerClosed {
-			log.Fatal(err)
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
 		}
-	}()
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Println("Shutting down server...")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	}
+	if cmd == nil {
+		return fmt.Errorf("%w: %s", errUsage, name)
+	}
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatal("Server forced to shutdown:", err)
+	conn, err := dbConfig.Connect(ctx, cfg.Database)
+	if err != nil {
+		return err
 	}
+	defer conn.Pool.Close()
 
-	log.Println("Server exited")
+	return cmd.run(ctx, &app{config: cfg, conn: conn, stdin: os.Stdin, stdout: os.Stdout}, args)
+}
 
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "usage: %s [flags] COMMAND\n\ncommands:\n", filepath.Base(os.Args[0]))
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s\n", cmd.usage)
+	}
+	fmt.Fprintln(w, "\nflags, each can also be set by the variable in brackets or in the config file:")
+	config.PrintFlags(w)
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"PilaiteProject/db/migration"
+	"PilaiteProject/internal/migrate"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate up [N] | down [N|all] | status | force VERSION"
+
+// runMigrate handles the migrate subcommands. up applies every pending migration unless N
+// is given, down rolls back one unless told otherwise, force clears a dirty state.
+func runMigrate(ctx context.Context, app *app, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := migrate.New(app.conn.Pool, migration.FS)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		steps := 0
+		if len(args) == 2 {
+			if steps, err = parseSteps(args[1]); err != nil {
+				return err
+			}
+		}
+		if err := applyMigrations(ctx, migrator, steps, app.stdout); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) == 2 && args[1] == "all" {
+			steps = 0
+		} else if len(args) == 2 {
+			if steps, err = parseSteps(args[1]); err != nil {
+				return err
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(app.stdout, "rolled back %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+	case "force":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+	case "status":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "version %d of %d, %d pending, dirty: %v\n", status.Version, status.Latest, status.Pending, status.Dirty)
+	return nil
+}
+
+// applyMigrations runs up to steps pending migrations and lists them, serve uses it too
+func applyMigrations(ctx context.Context, migrator *migrate.Migrator, steps int, w io.Writer) error {
+	applied, err := migrator.Up(ctx, steps)
+	for _, migration := range applied {
+		fmt.Fprintf(w, "applied %d_%s\n", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Fprintln(w, "no pending migrations")
+	}
+	return nil
+}
+
+func parseSteps(value string) (int, error) {
+	steps, err := strconv.Atoi(value)
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("invalid number of steps: %s", value)
+	}
+	return steps, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"PilaiteProject/db/seed"
+	"PilaiteProject/internal/importer"
+	"PilaiteProject/internal/service"
+	"bytes"
+	"context"
+	"errors"
+)
+
+// runSeed imports the sample spots. Spots that already exist are skipped, so it is
+// safe to run more than once.
+func runSeed(ctx context.Context, app *app, args []string) error {
+	if len(args) > 0 {
+		return errors.New("usage: seed")
+	}
+
+	spotService := service.NewSpotService(app.conn.Queries)
+	report, err := spotService.ImportSpots(ctx, importer.FormatCSV, bytes.NewReader(seed.SpotsCSV), false)
+	if err != nil {
+		return err
+	}
+	printImportReport(app.stdout, report)
+	if len(report.Errors) > 0 {
+		return errImportRejected
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"PilaiteProject/db/migration"
+	"PilaiteProject/internal/migrate"
+	"PilaiteProject/internal/server"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownTimeout is how long open requests get to finish after SIGINT or SIGTERM, on
+// top of the configured drain delay
+const shutdownTimeout = 10 * time.Second
+
+// runServe starts the HTTP server. With -migrate, or MIGRATE_ON_START=true, pending
+// migrations are applied first; the advisory lock makes instances starting together
+// take turns.
+func runServe(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	migrateOnStart := flags.Bool("migrate", app.config.MigrateOnStart, "apply pending migrations before starting")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errors.New("usage: serve [-migrate]")
+	}
+
+	if *migrateOnStart {
+		migrator, err := migrate.New(app.conn.Pool, migration.FS)
+		if err != nil {
+			return err
+		}
+		if err := applyMigrations(ctx, migrator, 0, app.stdout); err != nil {
+			return err
+		}
+	}
+
+	srv, err := server.NewServer(app.config.Server, app.conn)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := srv.Start(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-serveErr:
+		return err
+	case <-quit:
+	}
+
+	slog.Info("shutting down server")
+	ctx, cancel := context.WithTimeout(ctx, app.config.Server.DrainDelay+shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return errors.New("server forced to shutdown: " + err.Error())
+	}
+
+	slog.Info("server exited")
+	return nil
+}